@@ -1,7 +1,7 @@
 package mapper_test
 
 import (
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	. "github.com/onsi/ginkgo"
 
 	"github.com/Preskton/terraform-provider-twilio/helpers/mapper"
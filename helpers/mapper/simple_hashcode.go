@@ -5,7 +5,7 @@ import (
 	"fmt"
 
 	"github.com/fatih/structs"
-	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 // SimpleHashcode calculates a simple integer hashcode by iterating over all the fields/keys in a map,
@@ -36,7 +36,7 @@ func SimpleHashcode(v interface{}) int {
 		}
 	}
 
-	result := hashcode.String(buf.String())
+	result := schema.HashString(buf.String())
 
 	return result
 }
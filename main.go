@@ -1,7 +1,7 @@
 package main
 
 import (
-	"github.com/hashicorp/terraform/plugin"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 
 	"github.com/Preskton/terraform-provider-twilio/plugin/providers/twilio"
 
@@ -9,7 +9,7 @@ import (
 )
 
 func init() {
-	log.SetLevel(log.DebugLevel)
+	twilio.ConfigureLogging()
 }
 
 func main() {
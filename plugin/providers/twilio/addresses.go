@@ -0,0 +1,55 @@
+package twilio
+
+import (
+	"context"
+	"net/url"
+
+	twilio "github.com/kevinburke/twilio-go"
+)
+
+const addressesPath = "Addresses"
+
+// address is a customer address that phone numbers can be registered to. twilio-go doesn't wrap addresses, so we call
+// the API ourselves.
+type address struct {
+	Sid              string `json:"sid"`
+	AccountSid       string `json:"account_sid"`
+	CustomerName     string `json:"customer_name"`
+	FriendlyName     string `json:"friendly_name"`
+	Street           string `json:"street"`
+	StreetSecondary  string `json:"street_secondary"`
+	City             string `json:"city"`
+	Region           string `json:"region"`
+	PostalCode       string `json:"postal_code"`
+	IsoCountry       string `json:"iso_country"`
+	EmergencyEnabled bool   `json:"emergency_enabled"`
+	Validated        bool   `json:"validated"`
+	Verified         bool   `json:"verified"`
+	DateCreated      string `json:"date_created"`
+	DateUpdated      string `json:"date_updated"`
+}
+
+func getAddress(ctx context.Context, client *twilio.Client, sid string) (*address, error) {
+	a := new(address)
+	err := client.GetResource(ctx, addressesPath, sid, a)
+
+	return a, err
+}
+
+func createAddress(ctx context.Context, client *twilio.Client, data url.Values) (*address, error) {
+	a := new(address)
+	err := client.CreateResource(ctx, addressesPath, data, a)
+
+	return a, err
+}
+
+func updateAddress(ctx context.Context, client *twilio.Client, sid string, data url.Values) (*address, error) {
+	a := new(address)
+	err := client.UpdateResource(ctx, addressesPath, sid, data, a)
+
+	return a, err
+}
+
+func deleteAddress(ctx context.Context, client *twilio.Client, sid string) error {
+	return client.DeleteResource(ctx, addressesPath, sid)
+}
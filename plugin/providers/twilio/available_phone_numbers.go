@@ -0,0 +1,225 @@
+package twilio
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	twilio "github.com/kevinburke/twilio-go"
+)
+
+// availablePhoneNumber is a phone number that's available to buy. twilio-go's AvailableNumber leaves out the locality
+// and fax capability, so we decode the search results ourselves.
+type availablePhoneNumber struct {
+	FriendlyName        string `json:"friendly_name"`
+	PhoneNumber         string `json:"phone_number"`
+	Lata                string `json:"lata"`
+	Locality            string `json:"locality"`
+	RateCenter          string `json:"rate_center"`
+	Latitude            string `json:"latitude"`
+	Longitude           string `json:"longitude"`
+	Region              string `json:"region"`
+	PostalCode          string `json:"postal_code"`
+	ISOCountry          string `json:"iso_country"`
+	AddressRequirements string `json:"address_requirements"`
+	Beta                bool   `json:"beta"`
+	Capabilities        struct {
+		Voice bool `json:"voice"`
+		SMS   bool `json:"SMS"`
+		MMS   bool `json:"MMS"`
+		Fax   bool `json:"fax"`
+	} `json:"capabilities"`
+}
+
+type availablePhoneNumberPage struct {
+	Numbers []*availablePhoneNumber `json:"available_phone_numbers"`
+}
+
+// phoneNumberTypes maps the `number_type` values we accept onto the AvailablePhoneNumbers sub-resource that searches
+// for that type of number. twilio-go only wraps Local, Mobile and TollFree, so searches go through ListResource.
+var phoneNumberTypes = map[string]string{
+	"local":              "Local",
+	"mobile":             "Mobile",
+	"toll_free":          "TollFree",
+	"national":           "National",
+	"shared_cost":        "SharedCost",
+	"voip":               "Voip",
+	"machine_to_machine": "MachineToMachine",
+}
+
+// addressRequirementExclusions maps the `exclude_address_requirements` values we accept onto the search filter that
+// leaves out numbers with that address requirement.
+var addressRequirementExclusions = map[string]string{
+	"all":     "ExcludeAllAddressRequired",
+	"local":   "ExcludeLocalAddressRequired",
+	"foreign": "ExcludeForeignAddressRequired",
+}
+
+// searchCriteriaSchema describes the filters that can be used to narrow down a search for an available phone number.
+func searchCriteriaSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		MinItems: 0,
+		MaxItems: 1,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"sms_enabled": &schema.Schema{
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Description: "Only look for numbers that can send and receive SMS.",
+				},
+				"mms_enabled": &schema.Schema{
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Description: "Only look for numbers that can send and receive MMS.",
+				},
+				"voice_enabled": &schema.Schema{
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Description: "Only look for numbers that can make and receive calls.",
+				},
+				"fax_enabled": &schema.Schema{
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Description: "Only look for numbers that can send and receive faxes.",
+				},
+				"exclude_address_requirements": &schema.Schema{
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringInSlice([]string{"all", "local", "foreign"}, false),
+					Description:  "Leave out numbers that require an address. Can be `all` to leave out any number that requires an address, `local` for numbers that require a local address or `foreign` for numbers that require a foreign address.",
+				},
+				"beta": &schema.Schema{
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+					Description: "Whether or not numbers new to Twilio (beta status) can be returned. Defaults to `true`.",
+				},
+				"near_number": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Look for numbers geographically close to this phone number, within `distance` miles. US and Canada only.",
+				},
+				"near_lat_long": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Look for numbers geographically close to this `latitude,longitude`, within `distance` miles. US and Canada only.",
+				},
+				"distance": &schema.Schema{
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntBetween(0, 500),
+					Description:  "How far, in miles, from `near_number` or `near_lat_long` to look for numbers. Twilio defaults to 25 miles. US and Canada only.",
+				},
+				"in_postal_code": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Look for numbers in this postal code.",
+				},
+				"in_region": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Look for numbers in this region, such as a two letter US state.",
+				},
+				"in_rate_center": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Look for numbers in this rate center. Requires `in_lata` to also be set. US and Canada only.",
+				},
+				"in_lata": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Look for numbers in this local access and transport area (LATA). US and Canada only.",
+				},
+				"in_locality": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Look for numbers in this locality, such as a city or town.",
+				},
+			},
+		},
+	}
+}
+
+// makeSearchParams builds the search filters from the `area_code`, `search` and `search_criteria` attributes shared by
+// the twilio_phone_number resource and the twilio_available_phone_numbers data source.
+func makeSearchParams(d *schema.ResourceData) url.Values {
+	searchParams := make(url.Values)
+
+	addIfNotEmpty(searchParams, "AreaCode", d.Get("area_code"))
+	addIfNotEmpty(searchParams, "Contains", d.Get("search"))
+
+	if searchCriteria := d.Get("search_criteria").([]interface{}); len(searchCriteria) > 0 && searchCriteria[0] != nil {
+		addSearchCriteria(searchParams, searchCriteria[0].(map[string]interface{}))
+	}
+
+	return searchParams
+}
+
+// addSearchCriteria adds the filters from a `search_criteria` block to the search parameters. Capability filters are
+// only sent when enabled, so leaving one out doesn't exclude numbers that happen to have that capability.
+func addSearchCriteria(params url.Values, criteria map[string]interface{}) {
+	for attribute, filter := range map[string]string{
+		"sms_enabled":   "SmsEnabled",
+		"mms_enabled":   "MmsEnabled",
+		"voice_enabled": "VoiceEnabled",
+		"fax_enabled":   "FaxEnabled",
+	} {
+		if enabled, _ := criteria[attribute].(bool); enabled {
+			params.Set(filter, "true")
+		}
+	}
+
+	exclude, _ := criteria["exclude_address_requirements"].(string)
+
+	if exclusion, ok := addressRequirementExclusions[exclude]; ok {
+		params.Set(exclusion, "true")
+	}
+
+	if beta, ok := criteria["beta"].(bool); ok && !beta {
+		params.Set("Beta", "false")
+	}
+
+	if distance, _ := criteria["distance"].(int); distance > 0 {
+		params.Set("Distance", strconv.Itoa(distance))
+	}
+
+	addIfNotEmpty(params, "NearNumber", criteria["near_number"])
+	addIfNotEmpty(params, "NearLatLong", criteria["near_lat_long"])
+	addIfNotEmpty(params, "InPostalCode", criteria["in_postal_code"])
+	addIfNotEmpty(params, "InRegion", criteria["in_region"])
+	addIfNotEmpty(params, "InRateCenter", criteria["in_rate_center"])
+	addIfNotEmpty(params, "InLata", criteria["in_lata"])
+	addIfNotEmpty(params, "InLocality", criteria["in_locality"])
+}
+
+// phoneNumberTypeNames returns the accepted `number_type` values, sorted.
+func phoneNumberTypeNames() []string {
+	names := make([]string, 0, len(phoneNumberTypes))
+
+	for name := range phoneNumberTypes {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// searchAvailablePhoneNumbers returns a page of phone numbers of the given type that are available to buy in the
+// given country. Twilio only knows upper case country codes, so the country code is upper cased.
+func searchAvailablePhoneNumbers(ctx context.Context, client *twilio.Client, countryCode string, numberType string, filters url.Values) (*availablePhoneNumberPage, error) {
+	page := new(availablePhoneNumberPage)
+	path := "AvailablePhoneNumbers/" + strings.ToUpper(countryCode) + "/" + phoneNumberTypes[numberType]
+
+	if err := client.ListResource(ctx, path, filters, page); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
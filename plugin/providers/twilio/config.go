@@ -1,6 +1,15 @@
 package twilio
 
 import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/kevinburke/rest"
 	log "github.com/sirupsen/logrus"
 
 	twilio "github.com/kevinburke/twilio-go"
@@ -8,15 +17,54 @@ import (
 
 // Config contains our different configuration attributes and instantiates our Twilio client.
 type Config struct {
-	AccountSID string
-	AuthToken  string
-	Endpoint   string
+	AccountSID   string
+	AuthToken    string
+	APIKeySID    string
+	APIKeySecret string
+	Endpoint     string
+	Endpoints    map[string]string
+	MaxRetries   int
+	RetryMaxWait time.Duration
+
+	RequestsPerSecond     float64
+	MaxConcurrentRequests int
 }
 
 // TerraformTwilioContext is our Terraform context that will contain both our Twilio client and configuration for access downstream.
 type TerraformTwilioContext struct {
 	client        *twilio.Client
+	httpClient    *http.Client
+	rateLimiter   *RateLimiter
 	configuration Config
+
+	subaccountClientsLock sync.Mutex
+	subaccountClients     map[string]*twilio.Client
+}
+
+// endpointDomains lists the Twilio API domains whose base URL can be overridden, keyed by the name used in the `endpoints` block.
+var endpointDomains = []string{
+	"api",
+	"fax",
+	"insights",
+	"lookups",
+	"monitor",
+	"notify",
+	"pricing",
+	"taskrouter",
+	"verify",
+	"video",
+	"wireless",
+}
+
+// isEndpointDomain returns whether the given domain is one whose base URL can be overridden.
+func isEndpointDomain(domain string) bool {
+	for _, d := range endpointDomains {
+		if d == domain {
+			return true
+		}
+	}
+
+	return false
 }
 
 // Client creates a Twilio client and prepares it for use with Terraform.
@@ -24,17 +72,171 @@ func (config *Config) Client() (interface{}, error) {
 	log.WithFields(
 		log.Fields{
 			"account_sid": config.AccountSID,
+			"api_key_sid": config.APIKeySID,
+			"endpoint":    config.Endpoint,
 		},
 	).Debug("Initializing Twilio client")
 
-	// TODO Support unique endpoints
+	rateLimiter := NewRateLimiter(config.RequestsPerSecond, config.MaxConcurrentRequests)
+	httpClient := config.newHTTPClient(rateLimiter)
+	client, err := config.newTwilioClient(httpClient)
 
-	client := twilio.NewClient(config.AccountSID, config.AuthToken, nil)
+	if err != nil {
+		return nil, err
+	}
 
 	context := TerraformTwilioContext{
-		client:        client,
-		configuration: *config,
+		client:            client,
+		httpClient:        httpClient,
+		rateLimiter:       rateLimiter,
+		configuration:     *config,
+		subaccountClients: make(map[string]*twilio.Client),
 	}
 
 	return &context, nil
 }
+
+// newHTTPClient creates the HTTP client shared by every Twilio client. Each attempt at a request waits on the rate
+// limiter and is logged at debug level, and throttled or failed requests are retried.
+func (config *Config) newHTTPClient(rateLimiter *RateLimiter) *http.Client {
+	var transport http.RoundTripper = newLogTransport(rest.DefaultTransport, config.AuthToken, config.APIKeySecret)
+	transport = newRateLimitTransport(transport, rateLimiter)
+
+	return &http.Client{
+		Transport: newRetryTransport(transport, config.MaxRetries, config.RetryMaxWait),
+	}
+}
+
+// newTwilioClient creates a Twilio client using the configured credentials and endpoints.
+func (config *Config) newTwilioClient(httpClient *http.Client) (*twilio.Client, error) {
+	client := twilio.NewClient(config.AccountSID, config.AuthToken, httpClient)
+
+	if config.APIKeySID != "" {
+		config.useAPIKey(client)
+	}
+
+	if err := config.applyEndpoints(client); err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// accountSID returns the account a resource lives in: its `account_sid` attribute if set, otherwise the provider's account.
+func (c *TerraformTwilioContext) accountSID(d *schema.ResourceData) string {
+	if accountSID, ok := d.GetOk("account_sid"); ok {
+		return accountSID.(string)
+	}
+
+	return c.configuration.AccountSID
+}
+
+// clientForAccount returns a Twilio client whose requests are made against the given account SID using the parent
+// account's credentials. An empty account SID, or the parent account's own SID, returns the provider's client.
+// Subaccount clients are created on first use and cached for the life of the provider.
+func (c *TerraformTwilioContext) clientForAccount(accountSID string) (*twilio.Client, error) {
+	if accountSID == "" || accountSID == c.configuration.AccountSID {
+		return c.client, nil
+	}
+
+	c.subaccountClientsLock.Lock()
+	defer c.subaccountClientsLock.Unlock()
+
+	if client, ok := c.subaccountClients[accountSID]; ok {
+		return client, nil
+	}
+
+	log.WithFields(
+		log.Fields{
+			"parent_account_sid": c.configuration.AccountSID,
+			"account_sid":        accountSID,
+		},
+	).Debug("Initializing Twilio client for subaccount")
+
+	client, err := c.configuration.newTwilioClient(c.httpClient)
+
+	if err != nil {
+		return nil, err
+	}
+
+	client.RequestOnBehalfOf(accountSID)
+	c.subaccountClients[accountSID] = client
+
+	return client, nil
+}
+
+// baseURL returns the base URL to use for the given Twilio API domain, preferring a domain specific override
+// over the provider-wide endpoint. An empty string means the twilio-go default should be kept.
+func (config *Config) baseURL(domain string) (string, error) {
+	endpoint := config.Endpoints[domain]
+
+	if endpoint == "" {
+		endpoint = config.Endpoint
+	}
+
+	if endpoint == "" {
+		return "", nil
+	}
+
+	parsed, err := url.Parse(endpoint)
+
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return "", fmt.Errorf("Endpoint for the %s domain must be an absolute URL such as https://%s.twilio.com, got %q", domain, domain, endpoint)
+	}
+
+	return strings.TrimSuffix(endpoint, "/"), nil
+}
+
+// domainClients returns the Twilio client and each of its per-domain clients, keyed by domain.
+func domainClients(client *twilio.Client) map[string]*twilio.Client {
+	return map[string]*twilio.Client{
+		"api":        client,
+		"fax":        client.Fax,
+		"insights":   client.Insights,
+		"lookups":    client.Lookup,
+		"monitor":    client.Monitor,
+		"notify":     client.Notify,
+		"pricing":    client.Pricing,
+		"taskrouter": client.TaskRouter,
+		"verify":     client.Verify,
+		"video":      client.Video,
+		"wireless":   client.Wireless,
+	}
+}
+
+// useAPIKey switches the Twilio client and each of its per-domain clients to basic auth with the API key SID and secret.
+// Requests are still scoped to the configured account SID.
+func (config *Config) useAPIKey(client *twilio.Client) {
+	for _, c := range domainClients(client) {
+		c.ID = config.APIKeySID
+		c.Token = config.APIKeySecret
+	}
+}
+
+// applyEndpoints rewrites the base URL of the Twilio client and each of its per-domain clients.
+func (config *Config) applyEndpoints(client *twilio.Client) error {
+	clients := domainClients(client)
+
+	for _, domain := range endpointDomains {
+		base, err := config.baseURL(domain)
+
+		if err != nil {
+			return err
+		}
+
+		if base == "" {
+			continue
+		}
+
+		log.WithFields(
+			log.Fields{
+				"domain":   domain,
+				"base_url": base,
+			},
+		).Debug("Overriding Twilio endpoint")
+
+		clients[domain].Base = base
+	}
+
+	return nil
+}
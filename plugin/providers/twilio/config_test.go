@@ -0,0 +1,91 @@
+package twilio_test
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/Preskton/terraform-provider-twilio/plugin/providers/twilio"
+)
+
+var _ = Describe("Provider configuration", func() {
+	var (
+		server *fakeTwilioServer
+	)
+
+	BeforeEach(func() {
+		server = newFakeTwilioServer()
+		server.On("GET", accountPath("Keys/SK123"), 200, `{"sid": "SK123", "friendly_name": "woomy"}`)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should have a valid provider and resource schema", func() {
+		Expect(twilio.Provider().InternalValidate()).Should(Succeed())
+	})
+
+	Describe("Endpoints", func() {
+		Context("When `endpoint` is set", func() {
+			It("should send API requests to the configured endpoint", func() {
+				provider, err := configuredProvider(map[string]interface{}{
+					"endpoint": server.URL,
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				key := provider.ResourcesMap["twilio_api_key"]
+				d := key.TestResourceData()
+				d.SetId("SK123")
+
+				Expect(readResource(key, d, provider.Meta())).Should(Succeed())
+				Expect(d.Get("friendly_name")).To(Equal("woomy"))
+				Expect(server.Requests()).To(HaveLen(1))
+			})
+		})
+
+		Context("When only the `api` domain is overridden", func() {
+			It("should send core API requests to the override", func() {
+				provider, err := configuredProvider(map[string]interface{}{
+					"endpoint": "https://unused.example.com",
+					"endpoints": map[string]interface{}{
+						"api": server.URL + "/",
+					},
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				key := provider.ResourcesMap["twilio_api_key"]
+				d := key.TestResourceData()
+				d.SetId("SK123")
+
+				Expect(readResource(key, d, provider.Meta())).Should(Succeed())
+				Expect(server.Requests()).To(HaveLen(1))
+			})
+		})
+
+		Context("When `endpoints` overrides a domain the client can't route", func() {
+			It("should fail validation", func() {
+				diags := twilio.Provider().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+					"account_sid": fakeAccountSID,
+					"auth_token":  fakeAuthToken,
+					"endpoints": map[string]interface{}{
+						"api":       server.URL,
+						"messaging": server.URL,
+					},
+				}))
+
+				Expect(diagnosticsError(diags)).To(MatchError(ContainSubstring(`can't override the "messaging" domain`)))
+			})
+		})
+
+		Context("When the endpoint is not an absolute URL", func() {
+			It("should fail to configure the provider", func() {
+				_, err := configuredProvider(map[string]interface{}{
+					"endpoint": "localhost",
+				})
+
+				Expect(err).Should(HaveOccurred())
+			})
+		})
+	})
+})
@@ -0,0 +1,118 @@
+package twilio
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultCredentialsFile is where we look for credential profiles when `credentials_file` isn't set.
+const DefaultCredentialsFile = "~/.twilio-cli/config.json"
+
+// CredentialsProfile is a single named set of credentials in a credentials file.
+type CredentialsProfile struct {
+	AccountSID   string `json:"accountSid"`
+	AuthToken    string `json:"authToken"`
+	APIKeySID    string `json:"apiKey"`
+	APIKeySecret string `json:"apiSecret"`
+}
+
+// CredentialsFile mirrors the layout of the Twilio CLI's `config.json`: a set of named profiles.
+type CredentialsFile struct {
+	Profiles map[string]CredentialsProfile `json:"profiles"`
+}
+
+// expandHome replaces a leading `~` in path with the current user's home directory.
+func expandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+
+	if err != nil {
+		return "", fmt.Errorf("Unable to determine home directory to expand %s: %s", path, err)
+	}
+
+	return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+}
+
+// loadCredentialsProfile reads the credentials file at path and returns the named profile.
+func loadCredentialsProfile(path string, profile string) (*CredentialsProfile, error) {
+	expandedPath, err := expandHome(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	log.WithFields(
+		log.Fields{
+			"credentials_file": expandedPath,
+			"profile":          profile,
+		},
+	).Debug("Loading credentials profile")
+
+	contents, err := ioutil.ReadFile(expandedPath)
+
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read credentials file %s: %s", expandedPath, err)
+	}
+
+	var file CredentialsFile
+
+	if err := json.Unmarshal(contents, &file); err != nil {
+		return nil, fmt.Errorf("Unable to parse credentials file %s: %s", expandedPath, err)
+	}
+
+	credentials, ok := file.Profiles[profile]
+
+	if !ok {
+		return nil, fmt.Errorf("Profile %q not found in credentials file %s", profile, expandedPath)
+	}
+
+	return &credentials, nil
+}
+
+// resolveCredentials fills in any credentials missing from config using the given profile of the credentials file.
+// Explicit provider settings (including those sourced from environment variables) always win over the file.
+func (config *Config) resolveCredentials(credentialsFile string, profile string) error {
+	if profile != "" {
+		credentials, err := loadCredentialsProfile(credentialsFile, profile)
+
+		if err != nil {
+			return err
+		}
+
+		if config.AccountSID == "" {
+			config.AccountSID = credentials.AccountSID
+		}
+
+		if config.AuthToken == "" {
+			config.AuthToken = credentials.AuthToken
+		}
+
+		if config.APIKeySID == "" && config.APIKeySecret == "" {
+			config.APIKeySID = credentials.APIKeySID
+			config.APIKeySecret = credentials.APIKeySecret
+		}
+	}
+
+	if config.AccountSID == "" {
+		return fmt.Errorf("An account SID is required: set `account_sid`, the TWILIO_ACCOUNT_SID environment variable, or `profile`")
+	}
+
+	if (config.APIKeySID == "") != (config.APIKeySecret == "") {
+		return fmt.Errorf("`api_key_sid` and `api_key_secret` must be set together")
+	}
+
+	if config.AuthToken == "" && config.APIKeySID == "" {
+		return fmt.Errorf("An auth token or API key is required: set `auth_token` or `api_key_sid` and `api_key_secret`, their TWILIO_* environment variables, or `profile`")
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package twilio_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Provider credentials", func() {
+	var (
+		server          *fakeTwilioServer
+		dir             string
+		credentialsFile string
+		savedEnv        map[string]string
+		envNames        = []string{"TWILIO_ACCOUNT_SID", "TWILIO_AUTH_TOKEN", "TWILIO_API_KEY", "TWILIO_API_SECRET", "TWILIO_PROFILE", "TWILIO_CREDENTIALS_FILE"}
+	)
+
+	BeforeEach(func() {
+		server = newFakeTwilioServer()
+		server.On("GET", accountPath("Keys/SK123"), 200, `{"sid": "SK123", "friendly_name": "woomy"}`)
+
+		savedEnv = make(map[string]string)
+		for _, name := range envNames {
+			savedEnv[name] = os.Getenv(name)
+			os.Unsetenv(name)
+		}
+
+		var err error
+		dir, err = ioutil.TempDir("", "terraform-provider-twilio")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		credentialsFile = filepath.Join(dir, "config.json")
+		err = ioutil.WriteFile(credentialsFile, []byte(`{
+			"profiles": {
+				"woomy": {"accountSid": "`+fakeAccountSID+`", "authToken": "`+fakeAuthToken+`"},
+				"woomy-key": {"accountSid": "`+fakeAccountSID+`", "apiKey": "SK123", "apiSecret": "shh"}
+			}
+		}`), 0600)
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+		os.RemoveAll(dir)
+
+		for name, value := range savedEnv {
+			os.Setenv(name, value)
+		}
+	})
+
+	readKey := func(settings map[string]interface{}) error {
+		provider, err := rawConfiguredProvider(settings)
+		if err != nil {
+			return err
+		}
+
+		key := provider.ResourcesMap["twilio_api_key"]
+		d := key.TestResourceData()
+		d.SetId("SK123")
+
+		return readResource(key, d, provider.Meta())
+	}
+
+	Context("When credentials come from the environment", func() {
+		It("should authenticate with them", func() {
+			os.Setenv("TWILIO_ACCOUNT_SID", fakeAccountSID)
+			os.Setenv("TWILIO_AUTH_TOKEN", fakeAuthToken)
+
+			Expect(readKey(map[string]interface{}{"endpoint": server.URL})).Should(Succeed())
+		})
+	})
+
+	Context("When credentials come from a profile", func() {
+		It("should read them from the credentials file", func() {
+			Expect(readKey(map[string]interface{}{
+				"endpoint":         server.URL,
+				"profile":          "woomy",
+				"credentials_file": credentialsFile,
+			})).Should(Succeed())
+		})
+
+		It("should fail when the profile doesn't exist", func() {
+			err := readKey(map[string]interface{}{
+				"endpoint":         server.URL,
+				"profile":          "not-woomy",
+				"credentials_file": credentialsFile,
+			})
+
+			Expect(err).Should(MatchError(ContainSubstring("not-woomy")))
+		})
+	})
+
+	Context("When authenticating with an API key", func() {
+		It("should use the key for basic auth while scoping requests to the account", func() {
+			Expect(readKey(map[string]interface{}{
+				"endpoint":       server.URL,
+				"account_sid":    fakeAccountSID,
+				"api_key_sid":    "SK123",
+				"api_key_secret": "shh",
+			})).Should(Succeed())
+
+			requests := server.Requests()
+			Expect(requests).To(HaveLen(1))
+			Expect(requests[0].Path).To(Equal(accountPath("Keys/SK123")))
+			Expect(requests[0].Username).To(Equal("SK123"))
+			Expect(requests[0].Password).To(Equal("shh"))
+		})
+
+		It("should read the key from a profile", func() {
+			Expect(readKey(map[string]interface{}{
+				"endpoint":         server.URL,
+				"profile":          "woomy-key",
+				"credentials_file": credentialsFile,
+			})).Should(Succeed())
+
+			Expect(server.Requests()[0].Username).To(Equal("SK123"))
+		})
+
+		It("should require both the key SID and secret", func() {
+			err := readKey(map[string]interface{}{
+				"endpoint":    server.URL,
+				"account_sid": fakeAccountSID,
+				"api_key_sid": "SK123",
+			})
+
+			Expect(err).Should(MatchError(ContainSubstring("must be set together")))
+		})
+	})
+
+	Context("When no credentials are available", func() {
+		It("should fail to configure the provider", func() {
+			err := readKey(map[string]interface{}{"endpoint": server.URL})
+
+			Expect(err).Should(MatchError(ContainSubstring("account SID is required")))
+		})
+	})
+})
@@ -0,0 +1,220 @@
+package twilio
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	log "github.com/sirupsen/logrus"
+)
+
+func dataSourceTwilioAvailablePhoneNumbers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceTwilioAvailablePhoneNumbersRead,
+
+		Schema: map[string]*schema.Schema{
+			"account_sid": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "SID of the account to search from. Defaults to the provider's account.",
+			},
+			"country_code": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Two letter ISO country code in which you want to search for numbers.",
+			},
+			"number_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "local",
+				ValidateFunc: validation.StringInSlice(phoneNumberTypeNames(), false),
+				Description:  "The type of number to search for. Can be `local`, `mobile`, `toll_free`, `national`, `shared_cost`, `voip` or `machine_to_machine`, defaults to `local`.",
+			},
+			"search": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Look for this number sequence anywhere in the phone number.",
+			},
+			"area_code": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Look for numbers within this area code.",
+			},
+			"search_criteria": searchCriteriaSchema(),
+			"limit": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 1000),
+				Description:  "The most numbers to return. Twilio decides how many are returned when not set.",
+			},
+			"numbers": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The numbers available to buy that match the search.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"phone_number": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The phone number in E.164 format.",
+						},
+						"friendly_name": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The phone number formatted for display.",
+						},
+						"iso_country": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Two letter ISO country code of the number.",
+						},
+						"locality": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The locality, such as a city or town, of the number.",
+						},
+						"region": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The region, such as a two letter US state, of the number.",
+						},
+						"postal_code": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The postal code of the number.",
+						},
+						"lata": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The local access and transport area (LATA) of the number. US and Canada only.",
+						},
+						"rate_center": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The rate center of the number. US and Canada only.",
+						},
+						"latitude": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The latitude of the number. US and Canada only.",
+						},
+						"longitude": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The longitude of the number. US and Canada only.",
+						},
+						"address_requirements": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Address requirements imposed on this number: `none`, `any`, `local` or `foreign`.",
+						},
+						"is_beta": &schema.Schema{
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether or not this phone number is new to Twilio (beta status).",
+						},
+						"is_voice_capable": &schema.Schema{
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether or not this phone number is voice-capable.",
+						},
+						"is_sms_capable": &schema.Schema{
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether or not this phone number is SMS-capable.",
+						},
+						"is_mms_capable": &schema.Schema{
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether or not this phone number is MMS-capable.",
+						},
+						"is_fax_capable": &schema.Schema{
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether or not this phone number is fax-capable.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTwilioAvailablePhoneNumbersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Debug("ENTER dataSourceTwilioAvailablePhoneNumbersRead")
+
+	accountSID := meta.(*TerraformTwilioContext).accountSID(d)
+	client, err := meta.(*TerraformTwilioContext).clientForAccount(accountSID)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	countryCode := d.Get("country_code").(string)
+	numberType := d.Get("number_type").(string)
+	searchParams := makeSearchParams(d)
+
+	if limit := d.Get("limit").(int); limit > 0 {
+		searchParams.Set("PageSize", strconv.Itoa(limit))
+	}
+
+	log.WithFields(
+		log.Fields{
+			"account_sid":  accountSID,
+			"country_code": countryCode,
+			"number_type":  numberType,
+		},
+	).Debug("START searchAvailablePhoneNumbers")
+
+	searchResult, err := searchAvailablePhoneNumbers(ctx, client, countryCode, numberType, searchParams)
+
+	if err != nil {
+		return twilioErrorDiagnostics(fmt.Sprintf("Failed to search for phone numbers in %s", countryCode), err, phoneNumberErrorAttributes)
+	}
+
+	log.WithFields(
+		log.Fields{
+			"account_sid":  accountSID,
+			"country_code": countryCode,
+			"number_type":  numberType,
+			"result_count": len(searchResult.Numbers),
+		},
+	).Debug("END searchAvailablePhoneNumbers")
+
+	numbers := make([]interface{}, 0, len(searchResult.Numbers))
+
+	for _, number := range searchResult.Numbers {
+		numbers = append(numbers, map[string]interface{}{
+			"phone_number":         number.PhoneNumber,
+			"friendly_name":        number.FriendlyName,
+			"iso_country":          number.ISOCountry,
+			"locality":             number.Locality,
+			"region":               number.Region,
+			"postal_code":          number.PostalCode,
+			"lata":                 number.Lata,
+			"rate_center":          number.RateCenter,
+			"latitude":             number.Latitude,
+			"longitude":            number.Longitude,
+			"address_requirements": number.AddressRequirements,
+			"is_beta":              number.Beta,
+			"is_voice_capable":     number.Capabilities.Voice,
+			"is_sms_capable":       number.Capabilities.SMS,
+			"is_mms_capable":       number.Capabilities.MMS,
+			"is_fax_capable":       number.Capabilities.Fax,
+		})
+	}
+
+	if err := d.Set("numbers", numbers); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("account_sid", accountSID)
+	d.SetId(fmt.Sprintf("%s/%s/%s?%s", accountSID, countryCode, numberType, searchParams.Encode()))
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package twilio_test
+
+import (
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("twilio_available_phone_numbers", func() {
+	var (
+		server           *fakeTwilioServer
+		provider         *schema.Provider
+		availableNumbers *schema.Resource
+	)
+
+	BeforeEach(func() {
+		var err error
+
+		server = newFakeTwilioServer()
+		provider, err = configuredProvider(map[string]interface{}{"endpoint": server.URL})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		availableNumbers = provider.DataSourcesMap["twilio_available_phone_numbers"]
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should list the numbers Twilio found with their details", func() {
+		server.On(http.MethodGet, accountPath("AvailablePhoneNumbers/US/Mobile"), http.StatusOK, `{"available_phone_numbers": [
+			{
+				"friendly_name": "(415) 555-0100",
+				"phone_number": "+14155550100",
+				"lata": "722",
+				"locality": "San Francisco",
+				"rate_center": "SNFC CNTRL",
+				"latitude": "37.780000",
+				"longitude": "-122.420000",
+				"region": "CA",
+				"postal_code": "94103",
+				"iso_country": "US",
+				"address_requirements": "none",
+				"beta": false,
+				"capabilities": {"voice": true, "SMS": true, "MMS": false, "fax": true}
+			}
+		]}`)
+
+		d := availableNumbers.TestResourceData()
+		d.Set("country_code", "US")
+		d.Set("number_type", "mobile")
+		d.Set("area_code", "415")
+		d.Set("limit", 5)
+
+		Expect(readResource(availableNumbers, d, provider.Meta())).Should(Succeed())
+
+		Expect(server.Requests()[0].Form).To(Equal(map[string][]string{
+			"AreaCode": {"415"},
+			"PageSize": {"5"},
+		}))
+
+		Expect(d.Id()).NotTo(BeEmpty())
+		Expect(d.Get("numbers.#")).To(Equal(1))
+		Expect(d.Get("numbers.0")).To(Equal(map[string]interface{}{
+			"phone_number":         "+14155550100",
+			"friendly_name":        "(415) 555-0100",
+			"iso_country":          "US",
+			"locality":             "San Francisco",
+			"region":               "CA",
+			"postal_code":          "94103",
+			"lata":                 "722",
+			"rate_center":          "SNFC CNTRL",
+			"latitude":             "37.780000",
+			"longitude":            "-122.420000",
+			"address_requirements": "none",
+			"is_beta":              false,
+			"is_voice_capable":     true,
+			"is_sms_capable":       true,
+			"is_mms_capable":       false,
+			"is_fax_capable":       true,
+		}))
+	})
+
+	It("should return an empty list when nothing matches", func() {
+		server.On(http.MethodGet, accountPath("AvailablePhoneNumbers/GB/Local"), http.StatusOK, `{"available_phone_numbers": []}`)
+
+		d := availableNumbers.TestResourceData()
+		d.Set("country_code", "GB")
+		d.Set("number_type", "local")
+
+		Expect(readResource(availableNumbers, d, provider.Meta())).Should(Succeed())
+		Expect(d.Get("numbers")).To(BeEmpty())
+	})
+})
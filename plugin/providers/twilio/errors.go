@@ -0,0 +1,118 @@
+package twilio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/kevinburke/rest"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// isNotFound reports whether err is a Twilio API error saying the requested resource doesn't exist.
+func isNotFound(err error) bool {
+	rerr, ok := err.(*rest.Error)
+
+	return ok && rerr.Status == http.StatusNotFound
+}
+
+// removeFromStateIfNotFound clears the ID of d when err says the resource no longer exists in Twilio, so Terraform
+// plans to create it again rather than failing the refresh. It reports whether the resource was removed.
+func removeFromStateIfNotFound(d *schema.ResourceData, err error) bool {
+	if !isNotFound(err) {
+		return false
+	}
+
+	log.WithFields(
+		log.Fields{
+			"sid": d.Id(),
+		},
+	).Warn("Resource no longer exists in Twilio, removing it from state")
+
+	d.SetId("")
+
+	return true
+}
+
+// twilioErrorDetail describes err for a diagnostic. Twilio API errors are shown as `code: message` followed by the
+// link to Twilio's documentation for that code.
+func twilioErrorDetail(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "Timed out waiting on Twilio. Consider raising this resource's `timeouts`."
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return "The operation was cancelled."
+	}
+
+	rerr, ok := err.(*rest.Error)
+
+	if !ok {
+		return err.Error()
+	}
+
+	detail := rerr.Title
+
+	if rerr.ID != "" {
+		detail = fmt.Sprintf("%s: %s", rerr.ID, rerr.Title)
+	}
+
+	if rerr.Type != "" {
+		detail = fmt.Sprintf("%s\n\nMore info: %s", detail, rerr.Type)
+	}
+
+	return detail
+}
+
+// twilioErrorDiagnostics translates an error from a Twilio API call into diagnostics. summary says what we were
+// trying to do. If err is a Twilio API error whose code appears in codeAttributes, the diagnostic points at that
+// attribute so Terraform can show which part of the configuration caused it.
+func twilioErrorDiagnostics(summary string, err error, codeAttributes map[string]string) diag.Diagnostics {
+	diagnostic := diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  summary,
+		Detail:   twilioErrorDetail(err),
+	}
+
+	if rerr, ok := err.(*rest.Error); ok {
+		if attribute, ok := codeAttributes[rerr.ID]; ok {
+			diagnostic.AttributePath = attributePath(attribute)
+		}
+	}
+
+	return diag.Diagnostics{diagnostic}
+}
+
+// attributeErrorDiagnostics is a diagnostic for a problem with the given attribute that didn't come from Twilio.
+func attributeErrorDiagnostics(attribute string, summary string, detail string) diag.Diagnostics {
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       summary,
+			Detail:        detail,
+			AttributePath: attributePath(attribute),
+		},
+	}
+}
+
+// attributePath turns an attribute address such as `emergency.0.address_sid` into a path for a diagnostic.
+func attributePath(attribute string) cty.Path {
+	var path cty.Path
+
+	for _, part := range strings.Split(attribute, ".") {
+		if index, err := strconv.Atoi(part); err == nil {
+			path = path.IndexInt(index)
+		} else {
+			path = path.GetAttr(part)
+		}
+	}
+
+	return path
+}
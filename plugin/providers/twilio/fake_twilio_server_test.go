@@ -0,0 +1,224 @@
+package twilio_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/Preskton/terraform-provider-twilio/plugin/providers/twilio"
+)
+
+const (
+	fakeAccountSID = "AC00000000000000000000000000000000"
+	fakeAuthToken  = "00000000000000000000000000000000"
+)
+
+type fakeResponse struct {
+	status  int
+	headers map[string]string
+	body    string
+}
+
+type fakeRequest struct {
+	Method   string
+	Path     string
+	Form     map[string][]string
+	Username string
+	Password string
+}
+
+// fakeTwilioServer is a tiny stand-in for the Twilio API that serves canned responses and records every request it sees.
+type fakeTwilioServer struct {
+	*httptest.Server
+
+	// Delay holds every response for this long, to let concurrent requests pile up.
+	Delay time.Duration
+
+	mu          sync.Mutex
+	responses   map[string][]fakeResponse
+	requests    []fakeRequest
+	inFlight    int
+	maxInFlight int
+}
+
+func newFakeTwilioServer() *fakeTwilioServer {
+	f := &fakeTwilioServer{
+		responses: make(map[string][]fakeResponse),
+	}
+
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serveHTTP))
+
+	return f
+}
+
+// On registers a canned response for the given method and path, e.g. `On("GET", "/2010-04-01/Accounts/AC.../Keys/SK123.json", 200, "{...}")`.
+// Responses registered for the same method and path are served in order, with the last one repeating forever.
+func (f *fakeTwilioServer) On(method string, path string, status int, body string) {
+	f.OnWithHeaders(method, path, status, nil, body)
+}
+
+// OnWithHeaders is On, but also sets the given response headers.
+func (f *fakeTwilioServer) OnWithHeaders(method string, path string, status int, headers map[string]string, body string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := method + " " + path
+	f.responses[key] = append(f.responses[key], fakeResponse{status: status, headers: headers, body: body})
+}
+
+// Requests returns a copy of every request received so far.
+func (f *fakeTwilioServer) Requests() []fakeRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]fakeRequest(nil), f.requests...)
+}
+
+// MaxInFlight returns the most requests the server has been handling at once.
+func (f *fakeTwilioServer) MaxInFlight() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.maxInFlight
+}
+
+func (f *fakeTwilioServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	username, password, _ := r.BasicAuth()
+
+	f.mu.Lock()
+	f.inFlight++
+	if f.inFlight > f.maxInFlight {
+		f.maxInFlight = f.inFlight
+	}
+	f.mu.Unlock()
+
+	defer func() {
+		f.mu.Lock()
+		f.inFlight--
+		f.mu.Unlock()
+	}()
+
+	time.Sleep(f.Delay)
+
+	f.mu.Lock()
+	f.requests = append(f.requests, fakeRequest{Method: r.Method, Path: r.URL.Path, Form: r.Form, Username: username, Password: password})
+	key := r.Method + " " + r.URL.Path
+	responses := f.responses[key]
+	if len(responses) > 1 {
+		f.responses[key] = responses[1:]
+	}
+	f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if len(responses) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"code": 20404, "message": "The requested resource %s was not found", "more_info": "https://www.twilio.com/docs/errors/20404", "status": 404}`, r.URL.Path)
+		return
+	}
+
+	response := responses[0]
+
+	for name, value := range response.headers {
+		w.Header().Set(name, value)
+	}
+
+	w.WriteHeader(response.status)
+	fmt.Fprint(w, response.body)
+}
+
+// accountPath builds the path of an account scoped resource on the core API, e.g. accountPath("Keys/SK123").
+func accountPath(pathPart string) string {
+	return fmt.Sprintf("/2010-04-01/Accounts/%s/%s.json", fakeAccountSID, pathPart)
+}
+
+// configuredProvider returns a provider configured with the given raw provider settings on top of fake credentials.
+func configuredProvider(raw map[string]interface{}) (*schema.Provider, error) {
+	settings := map[string]interface{}{
+		"account_sid": fakeAccountSID,
+		"auth_token":  fakeAuthToken,
+	}
+
+	for key, value := range raw {
+		settings[key] = value
+	}
+
+	return rawConfiguredProvider(settings)
+}
+
+// rawConfiguredProvider returns a provider configured with exactly the given raw provider settings.
+func rawConfiguredProvider(settings map[string]interface{}) (*schema.Provider, error) {
+	provider := twilio.Provider()
+	diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(settings))
+
+	return provider, diagnosticsError(diags)
+}
+
+// diagnosticsError flattens any error diagnostics into a single error, so specs can use the usual error matchers.
+func diagnosticsError(diags diag.Diagnostics) error {
+	var messages []string
+
+	for _, d := range diags {
+		if d.Severity == diag.Error {
+			messages = append(messages, strings.TrimSpace(d.Summary+": "+d.Detail))
+		}
+	}
+
+	if len(messages) == 0 {
+		return nil
+	}
+
+	return errors.New(strings.Join(messages, "; "))
+}
+
+func createResource(r *schema.Resource, d *schema.ResourceData, meta interface{}) error {
+	return diagnosticsError(r.CreateContext(context.Background(), d, meta))
+}
+
+func readResource(r *schema.Resource, d *schema.ResourceData, meta interface{}) error {
+	return diagnosticsError(r.ReadContext(context.Background(), d, meta))
+}
+
+func updateResource(r *schema.Resource, d *schema.ResourceData, meta interface{}) error {
+	return diagnosticsError(r.UpdateContext(context.Background(), d, meta))
+}
+
+func deleteResource(r *schema.Resource, d *schema.ResourceData, meta interface{}) error {
+	return diagnosticsError(r.DeleteContext(context.Background(), d, meta))
+}
+
+// applyResource plans and applies the given config the way Terraform would, starting from state (nil to create the
+// resource), and returns the refreshed state.
+func applyResource(r *schema.Resource, state *terraform.InstanceState, config map[string]interface{}, meta interface{}) (*terraform.InstanceState, error) {
+	ctx := context.Background()
+	diff, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(config), meta)
+
+	if err != nil {
+		return nil, err
+	}
+
+	state, diags := r.Apply(ctx, state, diff, meta)
+
+	if diags.HasError() {
+		return nil, diagnosticsError(diags)
+	}
+
+	state, diags = r.RefreshWithoutUpgrade(ctx, state, meta)
+
+	return state, diagnosticsError(diags)
+}
+
+// planResource returns the changes Terraform would plan to make to get from state to the given config.
+func planResource(r *schema.Resource, state *terraform.InstanceState, config map[string]interface{}, meta interface{}) (*terraform.InstanceDiff, error) {
+	return r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+}
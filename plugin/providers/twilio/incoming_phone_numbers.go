@@ -0,0 +1,86 @@
+package twilio
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	twilio "github.com/kevinburke/twilio-go"
+)
+
+const incomingPhoneNumbersPath = "IncomingPhoneNumbers"
+
+// incomingPhoneNumber is a phone number owned by an account. twilio-go's IncomingPhoneNumber leaves out the address,
+// identity and bundle SIDs, the voice receive mode and the fax capability, so we call the API ourselves.
+type incomingPhoneNumber struct {
+	Sid                    string `json:"sid"`
+	AccountSid             string `json:"account_sid"`
+	PhoneNumber            string `json:"phone_number"`
+	FriendlyName           string `json:"friendly_name"`
+	AddressSid             string `json:"address_sid"`
+	AddressRequirements    string `json:"address_requirements"`
+	IdentitySid            string `json:"identity_sid"`
+	BundleSid              string `json:"bundle_sid"`
+	TrunkSid               string `json:"trunk_sid"`
+	Beta                   bool   `json:"beta"`
+	DateCreated            string `json:"date_created"`
+	DateUpdated            string `json:"date_updated"`
+	EmergencyStatus        string `json:"emergency_status"`
+	EmergencyAddressSid    string `json:"emergency_address_sid"`
+	EmergencyAddressStatus string `json:"emergency_address_status"`
+	SMSApplicationSid      string `json:"sms_application_sid"`
+	SMSFallbackMethod      string `json:"sms_fallback_method"`
+	SMSFallbackURL         string `json:"sms_fallback_url"`
+	SMSMethod              string `json:"sms_method"`
+	SMSURL                 string `json:"sms_url"`
+	StatusCallback         string `json:"status_callback"`
+	StatusCallbackMethod   string `json:"status_callback_method"`
+	VoiceApplicationSid    string `json:"voice_application_sid"`
+	VoiceCallerIDLookup    bool   `json:"voice_caller_id_lookup"`
+	VoiceFallbackMethod    string `json:"voice_fallback_method"`
+	VoiceFallbackURL       string `json:"voice_fallback_url"`
+	VoiceMethod            string `json:"voice_method"`
+	VoiceURL               string `json:"voice_url"`
+	VoiceReceiveMode       string `json:"voice_receive_mode"`
+	Capabilities           struct {
+		Voice bool `json:"voice"`
+		SMS   bool `json:"sms"`
+		MMS   bool `json:"mms"`
+		Fax   bool `json:"fax"`
+	} `json:"capabilities"`
+}
+
+// twilioDateFormat is how Twilio formats dates in the 2010-04-01 API, e.g. `Thu, 30 Jul 2015 20:12:31 +0000`.
+const twilioDateFormat = time.RFC1123Z
+
+// formatTwilioDate reformats a Twilio date as RFC 3339, or returns an empty string if it isn't set or can't be parsed.
+func formatTwilioDate(date string) string {
+	parsed, err := time.Parse(twilioDateFormat, date)
+
+	if err != nil {
+		return ""
+	}
+
+	return parsed.Format(time.RFC3339)
+}
+
+func getIncomingPhoneNumber(ctx context.Context, client *twilio.Client, sid string) (*incomingPhoneNumber, error) {
+	number := new(incomingPhoneNumber)
+	err := client.GetResource(ctx, incomingPhoneNumbersPath, sid, number)
+
+	return number, err
+}
+
+func createIncomingPhoneNumber(ctx context.Context, client *twilio.Client, data url.Values) (*incomingPhoneNumber, error) {
+	number := new(incomingPhoneNumber)
+	err := client.CreateResource(ctx, incomingPhoneNumbersPath, data, number)
+
+	return number, err
+}
+
+func updateIncomingPhoneNumber(ctx context.Context, client *twilio.Client, sid string, data url.Values) (*incomingPhoneNumber, error) {
+	number := new(incomingPhoneNumber)
+	err := client.UpdateResource(ctx, incomingPhoneNumbersPath, sid, data, number)
+
+	return number, err
+}
@@ -0,0 +1,161 @@
+package twilio
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
+	log "github.com/sirupsen/logrus"
+)
+
+const redacted = "[REDACTED]"
+
+var (
+	// terraformLogLevels maps Terraform's TF_LOG levels onto logrus levels.
+	terraformLogLevels = map[string]log.Level{
+		"TRACE": log.TraceLevel,
+		"DEBUG": log.DebugLevel,
+		"INFO":  log.InfoLevel,
+		"WARN":  log.WarnLevel,
+		"ERROR": log.ErrorLevel,
+	}
+
+	authorizationHeaderPattern = regexp.MustCompile(`(?mi)^(Authorization:[ \t]*)[^\r\n]*`)
+	jsonSecretPattern          = regexp.MustCompile(`(?i)("(?:auth_token|secret)"\s*:\s*)"[^"]*"`)
+	formSecretPattern          = regexp.MustCompile(`(?i)(^|[&?\s])((?:AuthToken|Secret)=)[^&\s]*`)
+)
+
+// TerraformFormatter formats logrus entries the way Terraform expects plugin logs: a `[LEVEL]` prefix Terraform uses
+// to filter by TF_LOG, followed by the message and its fields. Terraform adds its own timestamps.
+type TerraformFormatter struct{}
+
+// Format implements logrus.Formatter.
+func (f *TerraformFormatter) Format(entry *log.Entry) ([]byte, error) {
+	level := strings.ToUpper(entry.Level.String())
+
+	switch entry.Level {
+	case log.WarnLevel:
+		level = "WARN"
+	case log.FatalLevel, log.PanicLevel:
+		level = "ERROR"
+	}
+
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "[%s] %s", level, entry.Message)
+
+	keys := make([]string, 0, len(entry.Data))
+
+	for key := range entry.Data {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fmt.Fprintf(&b, " %s=%q", key, fmt.Sprint(entry.Data[key]))
+	}
+
+	b.WriteByte('\n')
+
+	return b.Bytes(), nil
+}
+
+// ConfigureLogging routes logrus through Terraform's plugin logging, so the provider only logs as verbosely as
+// TF_LOG asks for. With TF_LOG unset only warnings and errors are written.
+func ConfigureLogging() {
+	level, ok := terraformLogLevels[logging.LogLevel()]
+
+	if !ok {
+		level = log.WarnLevel
+	}
+
+	log.SetOutput(os.Stderr)
+	log.SetFormatter(&TerraformFormatter{})
+	log.SetLevel(level)
+}
+
+// logTransport is an http.RoundTripper that logs every request to and response from Twilio at debug level, with
+// credentials masked.
+type logTransport struct {
+	next    http.RoundTripper
+	secrets []string
+}
+
+func newLogTransport(next http.RoundTripper, secrets ...string) *logTransport {
+	t := &logTransport{next: next}
+
+	for _, secret := range secrets {
+		if secret != "" {
+			t.secrets = append(t.secrets, secret)
+		}
+	}
+
+	return t
+}
+
+// redact masks the Authorization header, auth tokens, API key secrets and any of the configured credentials in dump.
+func (t *logTransport) redact(dump []byte) string {
+	s := authorizationHeaderPattern.ReplaceAllString(string(dump), "${1}"+redacted)
+	s = jsonSecretPattern.ReplaceAllString(s, `${1}"`+redacted+`"`)
+	s = formSecretPattern.ReplaceAllString(s, "${1}${2}"+redacted)
+
+	for _, secret := range t.secrets {
+		s = strings.ReplaceAll(s, secret, redacted)
+	}
+
+	return s
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *logTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !log.IsLevelEnabled(log.DebugLevel) {
+		return t.next.RoundTrip(req)
+	}
+
+	if dump, err := t.dumpRequest(req); err != nil {
+		log.WithError(err).Warn("Unable to dump Twilio request")
+	} else {
+		log.Debugf("Twilio request:\n%s", t.redact(dump))
+	}
+
+	resp, err := t.next.RoundTrip(req)
+
+	if err != nil {
+		log.WithError(err).Debug("Twilio request failed")
+		return resp, err
+	}
+
+	if dump, err := httputil.DumpResponse(resp, true); err != nil {
+		log.WithError(err).Warn("Unable to dump Twilio response")
+	} else {
+		log.Debugf("Twilio response:\n%s", t.redact(dump))
+	}
+
+	return resp, nil
+}
+
+// dumpRequest dumps req without consuming its body, so the body can still be sent and rewound for retries.
+func (t *logTransport) dumpRequest(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.GetBody == nil {
+		return httputil.DumpRequestOut(req, false)
+	}
+
+	body, err := req.GetBody()
+
+	if err != nil {
+		return nil, err
+	}
+
+	clone := req.Clone(req.Context())
+	clone.Body = ioutil.NopCloser(body)
+
+	return httputil.DumpRequestOut(clone, true)
+}
@@ -0,0 +1,76 @@
+package twilio_test
+
+import (
+	"bytes"
+	"os"
+
+	"github.com/Preskton/terraform-provider-twilio/plugin/providers/twilio"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	log "github.com/sirupsen/logrus"
+)
+
+var _ = Describe("Logging", func() {
+	var output *bytes.Buffer
+
+	BeforeEach(func() {
+		output = &bytes.Buffer{}
+		log.SetOutput(output)
+		log.SetFormatter(&twilio.TerraformFormatter{})
+	})
+
+	AfterEach(func() {
+		log.SetOutput(os.Stderr)
+		log.SetFormatter(&log.TextFormatter{})
+	})
+
+	Context("TerraformFormatter", func() {
+		It("should prefix entries with the level Terraform filters on", func() {
+			log.WithFields(log.Fields{"sid": "PN123"}).Warn("Resource no longer exists")
+
+			Expect(output.String()).To(Equal("[WARN] Resource no longer exists sid=\"PN123\"\n"))
+		})
+	})
+
+	Context("When debug logging is enabled", func() {
+		var (
+			server   *fakeTwilioServer
+			provider *schema.Provider
+		)
+
+		BeforeEach(func() {
+			var err error
+
+			server = newFakeTwilioServer()
+			provider, err = configuredProvider(map[string]interface{}{
+				"endpoint":   server.URL,
+				"auth_token": "5ca1ab1e5ca1ab1e5ca1ab1e5ca1ab1e",
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("should log requests and responses with credentials masked", func() {
+			server.On("POST", accountPath("Keys"), 201, `{"sid": "SK123", "friendly_name": "woomy", "secret": "shh-its-a-secret"}`)
+			server.On("GET", accountPath("Keys/SK123"), 200, `{"sid": "SK123", "friendly_name": "woomy"}`)
+
+			key := provider.ResourcesMap["twilio_api_key"]
+			d := key.TestResourceData()
+			d.Set("friendly_name", "woomy")
+
+			Expect(createResource(key, d, provider.Meta())).Should(Succeed())
+
+			Expect(output.String()).To(ContainSubstring("Twilio request:"))
+			Expect(output.String()).To(ContainSubstring("Twilio response:"))
+			Expect(output.String()).To(ContainSubstring("Authorization: [REDACTED]"))
+			Expect(output.String()).To(ContainSubstring(`"secret": "[REDACTED]"`))
+			Expect(output.String()).NotTo(ContainSubstring("shh-its-a-secret"))
+			Expect(output.String()).NotTo(ContainSubstring("5ca1ab1e"))
+		})
+	})
+})
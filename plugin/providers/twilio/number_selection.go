@@ -0,0 +1,161 @@
+package twilio
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// numberSelection describes how to choose one phone number out of the numbers a search returned.
+type numberSelection struct {
+	strategy          string
+	closestTo         string
+	seed              int64
+	preferredPatterns []*regexp.Regexp
+}
+
+// numberSelectionStrategy picks one of the candidate numbers, which are in E.164 format and sorted lexically.
+type numberSelectionStrategy func(candidates []string, selection numberSelection) string
+
+// numberSelectionStrategies are the strategies that can be used for `selection`, keyed by name.
+var numberSelectionStrategies = map[string]numberSelectionStrategy{
+	"first":            nil, // Keeps the order Twilio returned the numbers in, so is handled before sorting
+	"lowest_lexical":   selectLowestLexical,
+	"closest_to":       selectClosestTo,
+	"pattern_score":    selectByPatternScore,
+	"random_with_seed": selectRandomWithSeed,
+}
+
+// resourceTwilioPhoneNumberSelectionDiff checks the selection can be made when planning, rather than after searching.
+func resourceTwilioPhoneNumberSelectionDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("selection") || !d.NewValueKnown("closest_to") {
+		return nil
+	}
+
+	if d.Get("selection").(string) == "closest_to" && d.Get("closest_to").(string) == "" {
+		return fmt.Errorf("closest_to must be set when selection is closest_to")
+	}
+
+	return nil
+}
+
+// numberSelectionStrategyNames returns the accepted `selection` values, sorted.
+func numberSelectionStrategyNames() []string {
+	names := make([]string, 0, len(numberSelectionStrategies))
+
+	for name := range numberSelectionStrategies {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// choose returns the candidate picked by the selection's strategy. Every strategy other than `first` sees the
+// candidates sorted, so the result only depends on which numbers Twilio returned and not the order they came in.
+func (s numberSelection) choose(candidates []string) string {
+	strategy := numberSelectionStrategies[s.strategy]
+
+	if strategy == nil {
+		return candidates[0]
+	}
+
+	sorted := append([]string(nil), candidates...)
+	sort.Strings(sorted)
+
+	return strategy(sorted, s)
+}
+
+func selectLowestLexical(candidates []string, selection numberSelection) string {
+	return candidates[0]
+}
+
+// selectClosestTo picks the number numerically closest to the `closest_to` number.
+func selectClosestTo(candidates []string, selection numberSelection) string {
+	target := digitsValue(selection.closestTo)
+	best := candidates[0]
+	bestDistance := distance(digitsValue(best), target)
+
+	for _, candidate := range candidates[1:] {
+		if d := distance(digitsValue(candidate), target); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+
+	return best
+}
+
+// selectByPatternScore picks the number with the highest pattern score, see patternScore.
+func selectByPatternScore(candidates []string, selection numberSelection) string {
+	best := candidates[0]
+	bestScore := patternScore(best, selection.preferredPatterns)
+
+	for _, candidate := range candidates[1:] {
+		if score := patternScore(candidate, selection.preferredPatterns); score > bestScore {
+			best, bestScore = candidate, score
+		}
+	}
+
+	return best
+}
+
+// selectRandomWithSeed picks a number at random, but always the same one for the same seed and candidates.
+func selectRandomWithSeed(candidates []string, selection numberSelection) string {
+	return candidates[rand.New(rand.NewSource(selection.seed)).Intn(len(candidates))]
+}
+
+// patternScore scores a number by the preferred patterns it matches, earlier patterns being worth more than later
+// ones. Matching any one pattern outweighs the longest run of a repeated digit, which breaks ties.
+func patternScore(number string, patterns []*regexp.Regexp) int {
+	score := 0
+
+	for i, pattern := range patterns {
+		if pattern.MatchString(number) {
+			score += (len(patterns) - i) * 100
+		}
+	}
+
+	return score + longestRepeatedDigitRun(number)
+}
+
+// longestRepeatedDigitRun returns the length of the longest run of the same digit in number.
+func longestRepeatedDigitRun(number string) int {
+	longest, run := 0, 0
+	var previous rune
+
+	for _, r := range number {
+		if r == previous {
+			run++
+		} else {
+			previous, run = r, 1
+		}
+
+		if r >= '0' && r <= '9' && run > longest {
+			longest = run
+		}
+	}
+
+	return longest
+}
+
+// digitsValue returns the digits of an E.164 number as an integer. E.164 numbers have at most 15 digits, so always fit.
+func digitsValue(number string) uint64 {
+	value, _ := strconv.ParseUint(strings.TrimPrefix(number, "+"), 10, 64)
+
+	return value
+}
+
+func distance(a uint64, b uint64) uint64 {
+	if a > b {
+		return a - b
+	}
+
+	return b - a
+}
@@ -0,0 +1,134 @@
+package twilio
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	twilio "github.com/kevinburke/twilio-go"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// emergencyPollInterval is how long to wait between checks on an emergency address's validation or registration.
+const emergencyPollInterval = 1 * time.Second
+
+const emergencyAddressAttribute = "emergency.0.address_sid"
+
+// emergencyAddressToActivate returns the SID of the address a new number, or a number whose emergency block changed, is
+// being activated for emergency calling with, or an empty string if it isn't.
+func emergencyAddressToActivate(d *schema.ResourceData) string {
+	if d.Id() != "" && !d.HasChange("emergency") {
+		return ""
+	}
+
+	emergency := singleBlock(d.Get("emergency"))
+
+	if emergency == nil || emergency["status"] != "active" {
+		return ""
+	}
+
+	sid, _ := emergency["address_sid"].(string)
+
+	return sid
+}
+
+// waitForEmergencyAddress waits for Twilio to validate an address before a number uses it for emergency calling.
+// Numbers can't be activated with an address that isn't validated, or that isn't enabled for emergency calling at all,
+// so this fails early rather than leaving the number half set up.
+func waitForEmergencyAddress(ctx context.Context, client *twilio.Client, sid string) diag.Diagnostics {
+	for {
+		log.WithFields(
+			log.Fields{
+				"address_sid": sid,
+			},
+		).Debug("START getAddress, waiting for emergency address validation")
+
+		a, err := getAddress(ctx, client, sid)
+
+		if isNotFound(err) {
+			return attributeErrorDiagnostics(emergencyAddressAttribute, "Emergency address not found",
+				fmt.Sprintf("Address SID %s doesn't exist in the phone number's account.", sid))
+		}
+
+		if err != nil {
+			return twilioErrorDiagnostics(fmt.Sprintf("Failed to check emergency address SID %s", sid), err, nil)
+		}
+
+		if !a.EmergencyEnabled {
+			return attributeErrorDiagnostics(emergencyAddressAttribute, "Address isn't enabled for emergency calling",
+				fmt.Sprintf("Set `emergency_enabled` on address SID %s before using it for emergency calling.", sid))
+		}
+
+		if a.Validated {
+			log.WithFields(
+				log.Fields{
+					"address_sid": sid,
+				},
+			).Debug("END getAddress, emergency address is validated")
+
+			return nil
+		}
+
+		if err := waitToPoll(ctx); err != nil {
+			return twilioErrorDiagnostics(fmt.Sprintf("Emergency address SID %s wasn't validated", sid), err, nil)
+		}
+	}
+}
+
+// waitForEmergencyRegistration waits for Twilio to finish registering, or unregistering, a number's emergency address,
+// and returns the number once it has. Twilio reports statuses such as `pending-registration` while it works and
+// `registration-failure` if it can't.
+func waitForEmergencyRegistration(ctx context.Context, client *twilio.Client, sid string) (*incomingPhoneNumber, diag.Diagnostics) {
+	for {
+		log.WithFields(
+			log.Fields{
+				"phone_number_sid": sid,
+			},
+		).Debug("START getIncomingPhoneNumber, waiting for emergency address registration")
+
+		ph, err := getIncomingPhoneNumber(ctx, client, sid)
+
+		if err != nil {
+			return nil, twilioErrorDiagnostics(fmt.Sprintf("Failed to refresh phone number SID %s", sid), err, nil)
+		}
+
+		status := ph.EmergencyAddressStatus
+
+		if strings.HasSuffix(status, "-failure") {
+			return nil, attributeErrorDiagnostics(emergencyAddressAttribute, "Emergency address registration failed",
+				fmt.Sprintf("Twilio reported `%s` for the emergency address of phone number SID %s. Check the address is correct and validated.", status, sid))
+		}
+
+		if !strings.HasPrefix(status, "pending-") {
+			log.WithFields(
+				log.Fields{
+					"phone_number_sid":         sid,
+					"emergency_address_status": status,
+				},
+			).Debug("END getIncomingPhoneNumber, emergency address registration finished")
+
+			return ph, nil
+		}
+
+		if err := waitToPoll(ctx); err != nil {
+			return nil, twilioErrorDiagnostics(fmt.Sprintf("Emergency address registration for phone number SID %s didn't finish", sid), err, nil)
+		}
+	}
+}
+
+// waitToPoll waits for the poll interval, or returns the context's error if it's done first.
+func waitToPoll(ctx context.Context) error {
+	timer := time.NewTimer(emergencyPollInterval)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,83 @@
+package twilio
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/spf13/cast"
+)
+
+// faxVoiceAttributes are the attributes the fax block shares with the voice block, as faxes go to the voice URLs.
+var faxVoiceAttributes = []string{"primary_url", "primary_http_method", "fallback_url", "fallback_http_method"}
+
+// resourceTwilioPhoneNumberFaxDiff plans the voice block from the fax block when it's set. Only the voice block is sent
+// to Twilio, and it can't be configured alongside the fax block, so without this it would plan its defaults and switch
+// the number back to voice.
+func resourceTwilioPhoneNumberFaxDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	fax := singleBlock(d.Get("fax"))
+
+	if fax == nil {
+		return resourceTwilioPhoneNumberFaxRemovedDiff(d)
+	}
+
+	planned := map[string]interface{}{
+		"receive_mode":      "fax",
+		"application_sid":   "",
+		"caller_id_enabled": false,
+	}
+
+	old, _ := d.GetChange("voice")
+
+	if voice := singleBlock(old); voice != nil {
+		planned["application_sid"] = voice["application_sid"]
+		planned["caller_id_enabled"] = voice["caller_id_enabled"]
+	}
+
+	for _, attribute := range faxVoiceAttributes {
+		planned[attribute] = fax[attribute]
+	}
+
+	// The voice block is planned as Twilio will store it, and it stores HTTP methods in upper case
+	for _, attribute := range []string{"primary_http_method", "fallback_http_method"} {
+		planned[attribute] = strings.ToUpper(cast.ToString(fax[attribute]))
+	}
+
+	return d.SetNew("voice", []interface{}{planned})
+}
+
+// resourceTwilioPhoneNumberFaxRemovedDiff plans the number back to taking calls, with the default URLs, when the fax
+// block is removed. The voice block is Computed, so unless it's configured in the fax block's place nothing in it is
+// planned and the number would stay in fax mode.
+func resourceTwilioPhoneNumberFaxRemovedDiff(d *schema.ResourceDiff) error {
+	oldFax, _ := d.GetChange("fax")
+	oldVoice, _ := d.GetChange("voice")
+	voice := singleBlock(oldVoice)
+
+	if singleBlock(oldFax) == nil || voice == nil || voice["receive_mode"] != "fax" {
+		return nil
+	}
+
+	if len(d.GetChangedKeysPrefix("voice.")) > 0 {
+		return nil
+	}
+
+	return d.SetNew("voice", []interface{}{map[string]interface{}{
+		"receive_mode":         "voice",
+		"application_sid":      voice["application_sid"],
+		"caller_id_enabled":    voice["caller_id_enabled"],
+		"primary_url":          "",
+		"primary_http_method":  "POST",
+		"fallback_url":         "",
+		"fallback_http_method": "POST",
+	}})
+}
+
+// singleBlock returns the attributes of a single item block, or nil if it isn't set.
+func singleBlock(value interface{}) map[string]interface{} {
+	if list, ok := value.([]interface{}); ok && len(list) > 0 && list[0] != nil {
+		return list[0].(map[string]interface{})
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+package twilio
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	log "github.com/sirupsen/logrus"
+	"github.com/ttacon/libphonenumber"
+)
+
+// phoneNumberSearchAttributes only decide which number is bought, so have no effect on a number that already exists.
+var phoneNumberSearchAttributes = []string{
+	"country_code",
+	"number_type",
+	"area_code",
+	"search",
+	"search_criteria",
+	"selection",
+	"preferred_patterns",
+	"closest_to",
+	"selection_seed",
+}
+
+// phoneNumberTypeMatches lists, for each `number_type`, the libphonenumber types a number of that type can have.
+// Types libphonenumber can't tell apart, such as `national`, are left out and always match.
+var phoneNumberTypeMatches = map[string][]libphonenumber.PhoneNumberType{
+	"local":       {libphonenumber.FIXED_LINE, libphonenumber.FIXED_LINE_OR_MOBILE},
+	"mobile":      {libphonenumber.MOBILE, libphonenumber.FIXED_LINE_OR_MOBILE},
+	"toll_free":   {libphonenumber.TOLL_FREE},
+	"shared_cost": {libphonenumber.SHARED_COST},
+	"voip":        {libphonenumber.VOIP},
+}
+
+// keypadDigits maps letters to the digit they share a phone keypad key with, as Twilio does for `Contains` searches.
+var keypadDigits = map[rune]rune{}
+
+func init() {
+	for digit, letters := range map[rune]string{'2': "abc", '3': "def", '4': "ghi", '5': "jkl", '6': "mno", '7': "pqrs", '8': "tuv", '9': "wxyz"} {
+		for _, letter := range letters {
+			keypadDigits[letter] = digit
+		}
+	}
+}
+
+// resourceTwilioPhoneNumberCustomizeDiff treats the search attributes as create-time inputs. Changing one on an
+// existing number just records the new value, unless the number no longer satisfies it and
+// `replace_on_search_change` is set, in which case a new number is bought to replace it.
+func resourceTwilioPhoneNumberCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	number := d.Get("number").(string)
+
+	for _, key := range phoneNumberSearchAttributes {
+		if !d.HasChange(key) {
+			continue
+		}
+
+		reason := unsatisfiedPhoneNumberSearch(d, key, number)
+
+		if reason == "" {
+			continue
+		}
+
+		fields := log.Fields{
+			"phone_number_sid": d.Id(),
+			"phone_number":     number,
+			"attribute":        key,
+			"reason":           reason,
+		}
+
+		if !d.Get("replace_on_search_change").(bool) {
+			log.WithFields(fields).Warn("Phone number no longer matches its search, keeping it as replace_on_search_change is false")
+			continue
+		}
+
+		log.WithFields(fields).Info("Phone number no longer matches its search, replacing it")
+
+		if err := d.ForceNew(key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// unsatisfiedPhoneNumberSearch explains why number doesn't satisfy the new value of the search attribute key, or
+// returns an empty string if it does or we can't tell without asking Twilio.
+func unsatisfiedPhoneNumberSearch(d *schema.ResourceDiff, key string, number string) string {
+	parsed, err := libphonenumber.Parse(number, "")
+
+	if err != nil {
+		return ""
+	}
+
+	switch key {
+	case "country_code":
+		countryCode := d.Get("country_code").(string)
+		region := libphonenumber.GetRegionCodeForNumber(parsed)
+
+		if region != "" && !strings.EqualFold(region, countryCode) {
+			return fmt.Sprintf("%s is in %s, not %s", number, region, countryCode)
+		}
+
+	case "number_type":
+		numberType := d.Get("number_type").(string)
+		types, ok := phoneNumberTypeMatches[numberType]
+		actual := libphonenumber.GetNumberType(parsed)
+
+		if !ok || actual == libphonenumber.UNKNOWN {
+			return ""
+		}
+
+		for _, t := range types {
+			if actual == t {
+				return ""
+			}
+		}
+
+		return fmt.Sprintf("%s isn't a %s number", number, numberType)
+
+	case "area_code":
+		areaCode := d.Get("area_code").(string)
+
+		if areaCode != "" && !strings.HasPrefix(libphonenumber.GetNationalSignificantNumber(parsed), areaCode) {
+			return fmt.Sprintf("%s isn't in area code %s", number, areaCode)
+		}
+
+	case "search":
+		search := d.Get("search").(string)
+
+		if search != "" && !searchPattern(search).MatchString(number) {
+			return fmt.Sprintf("%s doesn't match the search %q", number, search)
+		}
+
+	case "search_criteria":
+		return unsatisfiedSearchCriteria(d, number)
+	}
+
+	return ""
+}
+
+// unsatisfiedSearchCriteria checks the parts of `search_criteria` we can check against what we know of the number: its
+// capabilities and address requirements.
+func unsatisfiedSearchCriteria(d *schema.ResourceDiff, number string) string {
+	searchCriteria := d.Get("search_criteria").([]interface{})
+
+	if len(searchCriteria) == 0 || searchCriteria[0] == nil {
+		return ""
+	}
+
+	criteria := searchCriteria[0].(map[string]interface{})
+
+	for _, capability := range []string{"sms", "mms", "voice", "fax"} {
+		required, _ := criteria[capability+"_enabled"].(bool)
+
+		if required && !d.Get("is_"+capability+"_capable").(bool) {
+			return fmt.Sprintf("%s isn't %s capable", number, strings.ToUpper(capability))
+		}
+	}
+
+	exclude, _ := criteria["exclude_address_requirements"].(string)
+	addressRequirements := d.Get("address_requirements").(string)
+
+	if exclude != "" && addressRequirements != "" && addressRequirements != "none" && (exclude == "all" || exclude == addressRequirements) {
+		return fmt.Sprintf("%s requires a %s address", number, addressRequirements)
+	}
+
+	return ""
+}
+
+// searchPattern turns a Twilio `Contains` search into a regular expression: `*` matches any digit and letters match
+// the digit on the same keypad key.
+func searchPattern(search string) *regexp.Regexp {
+	var pattern strings.Builder
+
+	for _, r := range strings.ToLower(search) {
+		switch {
+		case r == '*':
+			pattern.WriteString(`\d`)
+		case keypadDigits[r] != 0:
+			pattern.WriteRune(keypadDigits[r])
+		default:
+			pattern.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+
+	return regexp.MustCompile(pattern.String())
+}
@@ -0,0 +1,129 @@
+package twilio
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/ttacon/libphonenumber"
+)
+
+var areaCodePattern = regexp.MustCompile(`^[0-9]+$`)
+
+// exampleNumberTypes are the types of example number libphonenumber keeps for each country, used to check whether an
+// area code could start a number in that country.
+var exampleNumberTypes = []libphonenumber.PhoneNumberType{
+	libphonenumber.FIXED_LINE,
+	libphonenumber.MOBILE,
+	libphonenumber.TOLL_FREE,
+	libphonenumber.PREMIUM_RATE,
+	libphonenumber.SHARED_COST,
+	libphonenumber.VOIP,
+	libphonenumber.PERSONAL_NUMBER,
+	libphonenumber.UAN,
+}
+
+// phoneNumberCapabilityBlocks are the blocks that only work on a number with the capability of the same name.
+var phoneNumberCapabilityBlocks = []string{"sms", "voice", "fax"}
+
+// resourceTwilioPhoneNumberValidateDiff catches mistakes in the search that Twilio would otherwise only report half way
+// through an apply: unknown countries, area codes that can't exist in the country and blocks an existing number can't
+// use. Which blocks a new number can use isn't known until it's found, see unusablePhoneNumberBlock.
+func resourceTwilioPhoneNumberValidateDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("country_code") {
+		return nil
+	}
+
+	countryCode := strings.ToUpper(d.Get("country_code").(string))
+
+	if _, ok := libphonenumber.GetSupportedRegions()[countryCode]; !ok {
+		return fmt.Errorf("country_code %q isn't a two letter ISO country code with phone numbers, such as US or GB", d.Get("country_code"))
+	}
+
+	if areaCode := d.Get("area_code").(string); areaCode != "" && d.NewValueKnown("area_code") {
+		if err := validateAreaCode(countryCode, areaCode); err != nil {
+			return err
+		}
+	}
+
+	if d.Id() == "" {
+		return nil
+	}
+
+	for _, block := range phoneNumberCapabilityBlocks {
+		if !d.Get("is_"+block+"_capable").(bool) && blockConfigured(d, block) {
+			return fmt.Errorf("%s isn't %s capable, so can't use the %s block", d.Get("number"), strings.ToUpper(block), block)
+		}
+	}
+
+	return nil
+}
+
+// validateAreaCode checks areaCode could start a number in the country, by putting it in place of the start of each of
+// the country's example numbers and seeing if any of them are valid. Countries without example numbers aren't checked.
+func validateAreaCode(countryCode string, areaCode string) error {
+	if !areaCodePattern.MatchString(areaCode) {
+		return fmt.Errorf("area_code %q must only contain digits", areaCode)
+	}
+
+	checked := false
+
+	for _, numberType := range exampleNumberTypes {
+		example := libphonenumber.GetExampleNumberForType(countryCode, numberType)
+
+		if example == nil {
+			continue
+		}
+
+		national := libphonenumber.GetNationalSignificantNumber(example)
+
+		if len(areaCode) >= len(national) {
+			continue
+		}
+
+		checked = true
+		candidate, err := libphonenumber.Parse(areaCode+national[len(areaCode):], countryCode)
+
+		if err == nil && libphonenumber.IsValidNumberForRegion(candidate, countryCode) {
+			return nil
+		}
+	}
+
+	if !checked {
+		return nil
+	}
+
+	return fmt.Errorf("area_code %q isn't an area code in %s", areaCode, countryCode)
+}
+
+// blockConfigured returns whether a block is being set. Blocks are Optional and Computed, so existing numbers always
+// have them in state and only count when the config changes them.
+func blockConfigured(d *schema.ResourceDiff, block string) bool {
+	if len(d.Get(block).([]interface{})) == 0 {
+		return false
+	}
+
+	return d.Id() == "" || d.HasChange(block)
+}
+
+// unusablePhoneNumberBlock returns the first block in config that a number with these capabilities can't use, or ""
+// if it can use all of them.
+func unusablePhoneNumberBlock(d *schema.ResourceData, capabilities map[string]bool) string {
+	for _, block := range phoneNumberCapabilityBlocks {
+		if len(d.Get(block).([]interface{})) > 0 && !capabilities[block] {
+			return block
+		}
+	}
+
+	return ""
+}
+
+func (n *availablePhoneNumber) capabilities() map[string]bool {
+	return map[string]bool{"sms": n.Capabilities.SMS, "voice": n.Capabilities.Voice, "fax": n.Capabilities.Fax}
+}
+
+func (n *incomingPhoneNumber) capabilities() map[string]bool {
+	return map[string]bool{"sms": n.Capabilities.SMS, "voice": n.Capabilities.Voice, "fax": n.Capabilities.Fax}
+}
@@ -1,19 +1,25 @@
 package twilio
 
 import (
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/terraform"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 var descriptions map[string]string
 
-// Provider returns a terraform.ResourceProvider.
-func Provider() terraform.ResourceProvider {
+// Provider returns a *schema.Provider.
+func Provider() *schema.Provider {
 	return &schema.Provider{
-		Schema:         providerSchema(),
-		DataSourcesMap: providerDataSourcesMap(),
-		ResourcesMap:   providerResources(),
-		ConfigureFunc:  providerConfigure,
+		Schema:               providerSchema(),
+		DataSourcesMap:       providerDataSourcesMap(),
+		ResourcesMap:         providerResources(),
+		ConfigureContextFunc: providerConfigure,
 	}
 }
 
@@ -22,20 +28,137 @@ func providerSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"account_sid": &schema.Schema{
 			Type:        schema.TypeString,
-			Required:    true,
-			Description: "The unique ID that identifies your Twilio account. Starts with `AC` and can be found on the Settings -> General page (https://www.twilio.com/console/project/settings).",
+			Optional:    true,
+			DefaultFunc: schema.EnvDefaultFunc("TWILIO_ACCOUNT_SID", ""),
+			Description: "The unique ID that identifies your Twilio account. Starts with `AC` and can be found on the Settings -> General page (https://www.twilio.com/console/project/settings). Can also be set with the `TWILIO_ACCOUNT_SID` environment variable or via `profile`.",
 		},
 		"auth_token": &schema.Schema{
 			Type:        schema.TypeString,
-			Required:    true,
-			Description: "Your secret token to access your Twilio account. Keep this safe - DO NOT check this into source control!",
+			Optional:    true,
+			Sensitive:   true,
+			DefaultFunc: schema.EnvDefaultFunc("TWILIO_AUTH_TOKEN", ""),
+			Description: "Your secret token to access your Twilio account. Keep this safe - DO NOT check this into source control! Can also be set with the `TWILIO_AUTH_TOKEN` environment variable or via `profile`.",
+		},
+		"api_key_sid": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			DefaultFunc: schema.EnvDefaultFunc("TWILIO_API_KEY", ""),
+			Description: "SID of an API key (starts with `SK`) to authenticate with instead of `auth_token`. Requests are still made against `account_sid`. Can also be set with the `TWILIO_API_KEY` environment variable.",
+		},
+		"api_key_secret": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			DefaultFunc: schema.EnvDefaultFunc("TWILIO_API_SECRET", ""),
+			Description: "Secret of the API key given in `api_key_sid`. Can also be set with the `TWILIO_API_SECRET` environment variable.",
+		},
+		"profile": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			DefaultFunc: schema.EnvDefaultFunc("TWILIO_PROFILE", ""),
+			Description: "Name of the profile in `credentials_file` to read credentials from when they aren't set directly. Can also be set with the `TWILIO_PROFILE` environment variable.",
+		},
+		"credentials_file": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			DefaultFunc: schema.EnvDefaultFunc("TWILIO_CREDENTIALS_FILE", DefaultCredentialsFile),
+			Description: "Path to a JSON credentials file containing named `profiles`, each with an `accountSid` and either an `authToken` or an `apiKey` and `apiSecret`. Defaults to `" + DefaultCredentialsFile + "`.",
 		},
 		"endpoint": &schema.Schema{
 			Type:        schema.TypeString,
 			Optional:    true,
 			Default:     "",
-			Description: "Allows you to change the Twilio API endpoint. Nearly everyone will leave this blank; Twilions may find use of this setting, though!",
+			Description: "Allows you to change the Twilio API endpoint. Nearly everyone will leave this blank; Twilions may find use of this setting, though! When set, every Twilio API domain is sent to this base URL unless overridden in `endpoints`.",
+		},
+		"max_retries": &schema.Schema{
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      DefaultMaxRetries,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "How many times to retry a Twilio request that was throttled (HTTP 429) or, for reads and deletes, failed with a server error. Set to `0` to disable retries.",
+		},
+		"retry_max_wait": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      DefaultRetryMaxWait.String(),
+			ValidateFunc: validateDuration,
+			Description:  "The longest to wait between retries, as a duration such as `30s`. Waits otherwise double from one second, or follow Twilio's `Retry-After` header.",
 		},
+		"rate_limit": &schema.Schema{
+			Type:     schema.TypeList,
+			MinItems: 0,
+			MaxItems: 1,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"requests_per_second": &schema.Schema{
+						Type:         schema.TypeFloat,
+						Optional:     true,
+						Default:      0,
+						ValidateFunc: validation.FloatAtLeast(0),
+						Description:  "The most Twilio API requests to send per second across all resources. Defaults to `0`, which is unlimited.",
+					},
+					"max_concurrent_requests": &schema.Schema{
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Default:      0,
+						ValidateFunc: validation.IntAtLeast(0),
+						Description:  "The most Twilio API requests to have in flight at once across all resources. Defaults to `0`, which is unlimited.",
+					},
+				},
+			},
+		},
+		"endpoints": &schema.Schema{
+			Type:         schema.TypeMap,
+			Optional:     true,
+			Elem:         &schema.Schema{Type: schema.TypeString},
+			ValidateFunc: validateEndpointDomains,
+			Description:  fmt.Sprintf("Base URLs used for individual Twilio API domains instead of the default, e.g. `api = \"https://api.dublin.ie1.twilio.com\"`. Can override %s.", strings.Join(endpointDomains, ", ")),
+		},
+	}
+}
+
+// validateEndpointDomains rejects endpoint overrides for domains the Twilio client can't be pointed at, rather than
+// silently sending those requests to the default endpoint.
+func validateEndpointDomains(value interface{}, key string) ([]string, []error) {
+	var errors []error
+
+	for domain := range value.(map[string]interface{}) {
+		if !isEndpointDomain(domain) {
+			errors = append(errors, fmt.Errorf("%s can't override the %q domain, expected one of %s", key, domain, strings.Join(endpointDomains, ", ")))
+		}
+	}
+
+	return nil, errors
+}
+
+// Schema for the `account_sid` attribute shared by resources that can be created inside a subaccount.
+func accountSIDSchema(resourceDescription string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		ForceNew:    true,
+		Description: fmt.Sprintf("SID of the account or subaccount that owns this %s. Defaults to the provider's `account_sid`; subaccounts are managed using the provider's credentials.", resourceDescription),
+	}
+}
+
+// Validates that a string attribute is a Go duration such as `30s` or `1m30s`.
+func validateDuration(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := time.ParseDuration(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a duration such as `30s`: %s", k, err))
+	}
+
+	return
+}
+
+// Default timeouts for resources whose CRUD operations are a handful of Twilio API calls.
+func defaultResourceTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(10 * time.Minute),
+		Read:   schema.DefaultTimeout(5 * time.Minute),
+		Update: schema.DefaultTimeout(10 * time.Minute),
+		Delete: schema.DefaultTimeout(10 * time.Minute),
 	}
 }
 
@@ -45,19 +168,58 @@ func providerResources() map[string]*schema.Resource {
 		"twilio_phone_number": resourceTwilioPhoneNumber(),
 		"twilio_subaccount":   resourceTwilioSubaccount(),
 		"twilio_api_key":      resourceTwilioApiKey(),
+		"twilio_address":      resourceTwilioAddress(),
 	}
 }
 
 // List of supported data sources and their configuration fields.
 func providerDataSourcesMap() map[string]*schema.Resource {
-	return map[string]*schema.Resource{}
+	return map[string]*schema.Resource{
+		"twilio_available_phone_numbers": dataSourceTwilioAvailablePhoneNumbers(),
+	}
 }
 
-func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	config := Config{
-		AccountSID: d.Get("account_sid").(string),
-		AuthToken:  d.Get("auth_token").(string),
-		Endpoint:   d.Get("endpoint").(string),
+		AccountSID:   d.Get("account_sid").(string),
+		AuthToken:    d.Get("auth_token").(string),
+		APIKeySID:    d.Get("api_key_sid").(string),
+		APIKeySecret: d.Get("api_key_secret").(string),
+		Endpoint:     d.Get("endpoint").(string),
+		Endpoints:    make(map[string]string),
+		MaxRetries:   d.Get("max_retries").(int),
+	}
+
+	if rateLimit := d.Get("rate_limit").([]interface{}); len(rateLimit) > 0 && rateLimit[0] != nil {
+		rateLimit := rateLimit[0].(map[string]interface{})
+
+		config.RequestsPerSecond = rateLimit["requests_per_second"].(float64)
+		config.MaxConcurrentRequests = rateLimit["max_concurrent_requests"].(int)
 	}
-	return config.Client()
+
+	retryMaxWait, err := time.ParseDuration(d.Get("retry_max_wait").(string))
+
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	config.RetryMaxWait = retryMaxWait
+
+	for domain, endpoint := range d.Get("endpoints").(map[string]interface{}) {
+		config.Endpoints[domain] = endpoint.(string)
+	}
+
+	err = config.resolveCredentials(d.Get("credentials_file").(string), d.Get("profile").(string))
+
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	client, err := config.Client()
+
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	return client, nil
 }
@@ -0,0 +1,87 @@
+package twilio
+
+import (
+	"net/http"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimiter throttles Twilio API calls shared by every resource in a provider: a token bucket bounds the request
+// rate and a semaphore bounds how many requests are in flight at once. A zero limit disables that half of the limiter.
+type RateLimiter struct {
+	limiter   *rate.Limiter
+	semaphore chan struct{}
+}
+
+// NewRateLimiter creates a RateLimiter allowing requestsPerSecond requests per second (with bursts of up to one
+// second's worth) and at most maxConcurrentRequests requests at a time.
+func NewRateLimiter(requestsPerSecond float64, maxConcurrentRequests int) *RateLimiter {
+	l := &RateLimiter{}
+
+	if requestsPerSecond > 0 {
+		burst := int(requestsPerSecond)
+
+		if burst < 1 {
+			burst = 1
+		}
+
+		l.limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
+	}
+
+	if maxConcurrentRequests > 0 {
+		l.semaphore = make(chan struct{}, maxConcurrentRequests)
+	}
+
+	return l
+}
+
+// rateLimitTransport is an http.RoundTripper that waits for the RateLimiter before sending each request. The
+// concurrency slot is held until the response body is closed.
+type rateLimitTransport struct {
+	next    http.RoundTripper
+	limiter *RateLimiter
+}
+
+func newRateLimitTransport(next http.RoundTripper, limiter *RateLimiter) *rateLimitTransport {
+	return &rateLimitTransport{
+		next:    next,
+		limiter: limiter,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if t.limiter.semaphore != nil {
+		select {
+		case t.limiter.semaphore <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	release := func() {
+		if t.limiter.semaphore != nil {
+			<-t.limiter.semaphore
+		}
+	}
+
+	if t.limiter.limiter != nil {
+		if err := t.limiter.limiter.Wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	resp, err := t.next.RoundTrip(req)
+
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	resp.Body = newOnCloseBody(resp.Body, release)
+
+	return resp, nil
+}
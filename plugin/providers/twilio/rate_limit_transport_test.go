@@ -0,0 +1,102 @@
+package twilio_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Rate limiting", func() {
+	var (
+		server *fakeTwilioServer
+	)
+
+	BeforeEach(func() {
+		server = newFakeTwilioServer()
+		server.On("GET", accountPath("Keys/SK123"), 200, `{"sid": "SK123", "friendly_name": "woomy"}`)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	readKeysConcurrently := func(provider *schema.Provider, count int) {
+		key := provider.ResourcesMap["twilio_api_key"]
+
+		var wg sync.WaitGroup
+
+		for i := 0; i < count; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+
+				d := key.TestResourceData()
+				d.SetId("SK123")
+
+				Expect(readResource(key, d, provider.Meta())).Should(Succeed())
+			}()
+		}
+
+		wg.Wait()
+	}
+
+	Context("When `max_concurrent_requests` is set", func() {
+		It("should never have more requests in flight than allowed", func() {
+			server.Delay = 20 * time.Millisecond
+
+			provider, err := configuredProvider(map[string]interface{}{
+				"endpoint": server.URL,
+				"rate_limit": []interface{}{
+					map[string]interface{}{
+						"max_concurrent_requests": 2,
+					},
+				},
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			readKeysConcurrently(provider, 10)
+
+			Expect(server.Requests()).To(HaveLen(10))
+			Expect(server.MaxInFlight()).To(Equal(2))
+		})
+	})
+
+	Context("When `requests_per_second` is set", func() {
+		It("should spread requests out over time", func() {
+			provider, err := configuredProvider(map[string]interface{}{
+				"endpoint": server.URL,
+				"rate_limit": []interface{}{
+					map[string]interface{}{
+						"requests_per_second": 20.0,
+					},
+				},
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			start := time.Now()
+			readKeysConcurrently(provider, 30)
+
+			// The first 20 requests use up the burst, the remaining 10 trickle out at 20 per second
+			Expect(time.Since(start)).To(BeNumerically(">=", 400*time.Millisecond))
+			Expect(server.Requests()).To(HaveLen(30))
+		})
+	})
+
+	Context("When no limits are set", func() {
+		It("should let requests through concurrently", func() {
+			server.Delay = 20 * time.Millisecond
+
+			provider, err := configuredProvider(map[string]interface{}{"endpoint": server.URL})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			readKeysConcurrently(provider, 5)
+
+			Expect(server.MaxInFlight()).To(BeNumerically(">", 1))
+		})
+	})
+})
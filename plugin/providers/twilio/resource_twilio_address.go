@@ -0,0 +1,375 @@
+package twilio
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// addressErrorAttributes points Twilio's address validation errors at the attribute to fix.
+var addressErrorAttributes = map[string]string{
+	"21628": "street",
+	"21629": "street",
+}
+
+// addressParams maps the address attributes onto the parameters Twilio takes for them.
+var addressParams = map[string]string{
+	"customer_name":     "CustomerName",
+	"friendly_name":     "FriendlyName",
+	"street":            "Street",
+	"street_secondary":  "StreetSecondary",
+	"city":              "City",
+	"region":            "Region",
+	"postal_code":       "PostalCode",
+	"iso_country":       "IsoCountry",
+	"emergency_enabled": "EmergencyEnabled",
+	"auto_correct":      "AutoCorrectAddress",
+}
+
+var addressPunctuation = regexp.MustCompile(`[.,]+`)
+
+func resourceTwilioAddress() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceTwilioAddressCreate,
+		ReadContext:   resourceTwilioAddressRead,
+		UpdateContext: resourceTwilioAddressUpdate,
+		DeleteContext: resourceTwilioAddressDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceTwilioAddressImport,
+		},
+		Timeouts: defaultResourceTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			"sid": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The unique identifier for this address.",
+			},
+			"account_sid": accountSIDSchema("address"),
+			"customer_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the person or business at the address.",
+			},
+			"friendly_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "A friendly, human-readable name by which you can refer to this address.",
+			},
+			"street": &schema.Schema{
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: suppressAutoCorrectedAddress,
+				Description:      "The number and street of the address.",
+			},
+			"street_secondary": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressAutoCorrectedAddress,
+				Description:      "The rest of the street address, such as an apartment or suite number.",
+			},
+			"city": &schema.Schema{
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: suppressAutoCorrectedAddress,
+				Description:      "The city of the address.",
+			},
+			"region": &schema.Schema{
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: suppressAutoCorrectedAddress,
+				Description:      "The region of the address, such as a two letter US state.",
+			},
+			"postal_code": &schema.Schema{
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: suppressAutoCorrectedAddress,
+				Description:      "The postal code of the address.",
+			},
+			"iso_country": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[A-Z]{2}$`), "must be a two letter ISO country code, such as US"),
+				Description:  "Two letter ISO country code of the address. Changing it creates a new address.",
+			},
+			"emergency_enabled": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether or not the address can be used for emergency calling. Twilio validates the address when it's enabled. Defaults to `false`.",
+			},
+			"auto_correct": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether or not Twilio may correct the address, such as fixing the case of the street, when validating it. Corrections that only change case, spacing or punctuation aren't planned as changes. Defaults to `true`.",
+			},
+			"validated": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether or not Twilio has validated the address.",
+			},
+			"verified": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether or not Twilio has verified the address, which some countries require before numbers can use it.",
+			},
+			"date_created": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date the address was created.",
+			},
+			"date_updated": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date the address was last updated.",
+			},
+		},
+	}
+}
+
+// suppressAutoCorrectedAddress ignores differences Twilio makes when auto-correcting an address, which only change case,
+// spacing or punctuation, so they aren't planned as changes and sent back to be corrected again.
+func suppressAutoCorrectedAddress(k, old, new string, d *schema.ResourceData) bool {
+	if !d.Get("auto_correct").(bool) {
+		return false
+	}
+
+	return normalizeAddressPart(old) == normalizeAddressPart(new)
+}
+
+func normalizeAddressPart(part string) string {
+	return strings.ToLower(strings.Join(strings.Fields(addressPunctuation.ReplaceAllString(part, " ")), " "))
+}
+
+// makeAddressPayload returns the parameters for the given address attributes, skipping empty strings when creating.
+func makeAddressPayload(d *schema.ResourceData, attributes []string, skipEmpty bool) url.Values {
+	payload := make(url.Values)
+
+	for _, attribute := range attributes {
+		switch value := d.Get(attribute).(type) {
+		case bool:
+			payload.Set(addressParams[attribute], strconv.FormatBool(value))
+		case string:
+			if value != "" || !skipEmpty {
+				payload.Set(addressParams[attribute], value)
+			}
+		}
+	}
+
+	return payload
+}
+
+func mapTwilioAddressToTerraform(a *address, d *schema.ResourceData) {
+	d.Set("sid", a.Sid)
+	d.Set("account_sid", a.AccountSid)
+	d.Set("customer_name", a.CustomerName)
+	d.Set("friendly_name", a.FriendlyName)
+	d.Set("street", a.Street)
+	d.Set("street_secondary", a.StreetSecondary)
+	d.Set("city", a.City)
+	d.Set("region", a.Region)
+	d.Set("postal_code", a.PostalCode)
+	d.Set("iso_country", a.IsoCountry)
+	d.Set("emergency_enabled", a.EmergencyEnabled)
+	d.Set("validated", a.Validated)
+	d.Set("verified", a.Verified)
+	d.Set("date_created", formatTwilioDate(a.DateCreated))
+	d.Set("date_updated", formatTwilioDate(a.DateUpdated))
+}
+
+func resourceTwilioAddressCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Debug("ENTER resourceTwilioAddressCreate")
+
+	accountSID := meta.(*TerraformTwilioContext).accountSID(d)
+	client, err := meta.(*TerraformTwilioContext).clientForAccount(accountSID)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	attributes := make([]string, 0, len(addressParams))
+
+	for attribute := range addressParams {
+		attributes = append(attributes, attribute)
+	}
+
+	createParams := makeAddressPayload(d, attributes, true)
+
+	log.WithFields(
+		log.Fields{
+			"account_sid": accountSID,
+			"iso_country": d.Get("iso_country"),
+		},
+	).Debug("START createAddress")
+
+	createResult, err := createAddress(ctx, client, createParams)
+
+	if err != nil {
+		return twilioErrorDiagnostics("Failed to create address", err, addressErrorAttributes)
+	}
+
+	log.WithFields(
+		log.Fields{
+			"account_sid": accountSID,
+			"address_sid": createResult.Sid,
+		},
+	).Debug("END createAddress")
+
+	d.SetId(createResult.Sid)
+	mapTwilioAddressToTerraform(createResult, d)
+
+	return nil
+}
+
+func resourceTwilioAddressRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Debug("ENTER resourceTwilioAddressRead")
+
+	accountSID := meta.(*TerraformTwilioContext).accountSID(d)
+	client, err := meta.(*TerraformTwilioContext).clientForAccount(accountSID)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	sid := d.Id()
+
+	log.WithFields(
+		log.Fields{
+			"account_sid": accountSID,
+			"address_sid": sid,
+		},
+	).Debug("START getAddress")
+
+	a, err := getAddress(ctx, client, sid)
+
+	if removeFromStateIfNotFound(d, err) {
+		return nil
+	}
+
+	if err != nil {
+		return twilioErrorDiagnostics(fmt.Sprintf("Failed to refresh address SID %s", sid), err, nil)
+	}
+
+	mapTwilioAddressToTerraform(a, d)
+
+	return nil
+}
+
+func resourceTwilioAddressUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Debug("ENTER resourceTwilioAddressUpdate")
+
+	accountSID := meta.(*TerraformTwilioContext).accountSID(d)
+	client, err := meta.(*TerraformTwilioContext).clientForAccount(accountSID)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	sid := d.Id()
+	changed := make([]string, 0, len(addressParams))
+
+	for attribute := range addressParams {
+		if d.HasChange(attribute) {
+			changed = append(changed, attribute)
+		}
+	}
+
+	if len(changed) == 0 {
+		return resourceTwilioAddressRead(ctx, d, meta)
+	}
+
+	// Twilio only corrects what it's sent, so tell it whether it may each time
+	changed = append(changed, "auto_correct")
+
+	log.WithFields(
+		log.Fields{
+			"account_sid": accountSID,
+			"address_sid": sid,
+		},
+	).Debug("START updateAddress")
+
+	updateResult, err := updateAddress(ctx, client, sid, makeAddressPayload(d, changed, false))
+
+	if err != nil {
+		return twilioErrorDiagnostics(fmt.Sprintf("Failed to update address SID %s", sid), err, addressErrorAttributes)
+	}
+
+	log.WithFields(
+		log.Fields{
+			"account_sid": accountSID,
+			"address_sid": sid,
+		},
+	).Debug("END updateAddress")
+
+	mapTwilioAddressToTerraform(updateResult, d)
+
+	return nil
+}
+
+func resourceTwilioAddressDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Debug("ENTER resourceTwilioAddressDelete")
+
+	accountSID := meta.(*TerraformTwilioContext).accountSID(d)
+	client, err := meta.(*TerraformTwilioContext).clientForAccount(accountSID)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	sid := d.Id()
+
+	log.WithFields(
+		log.Fields{
+			"account_sid": accountSID,
+			"address_sid": sid,
+		},
+	).Debug("START deleteAddress")
+
+	err = deleteAddress(ctx, client, sid)
+
+	if err != nil && !isNotFound(err) {
+		return twilioErrorDiagnostics(fmt.Sprintf("Failed to delete address SID %s", sid), err, nil)
+	}
+
+	log.WithFields(
+		log.Fields{
+			"account_sid": accountSID,
+			"address_sid": sid,
+		},
+	).Debug("END deleteAddress")
+
+	return nil
+}
+
+// resourceTwilioAddressImport imports an address by its SID, or by `account_sid/SID` for addresses in a subaccount.
+func resourceTwilioAddressImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	log.Debug("ENTER resourceTwilioAddressImport")
+
+	id := d.Id()
+
+	if parts := strings.SplitN(id, "/", 2); len(parts) == 2 {
+		if !strings.HasPrefix(parts[0], "AC") {
+			return nil, fmt.Errorf("Expected an account SID before the / in %q, such as AC123.../AD123...", id)
+		}
+
+		d.Set("account_sid", parts[0])
+		d.SetId(parts[1])
+	}
+
+	// Twilio doesn't say whether it may correct an address, so assume the default
+	d.Set("auto_correct", true)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,216 @@
+package twilio_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("twilio_address", func() {
+	var (
+		server   *fakeTwilioServer
+		provider *schema.Provider
+		address  *schema.Resource
+		config   map[string]interface{}
+	)
+
+	twilioAddress := func(overrides map[string]interface{}) string {
+		a := map[string]interface{}{
+			"sid":               "AD123",
+			"account_sid":       fakeAccountSID,
+			"customer_name":     "Woomy Inc",
+			"friendly_name":     "Head office",
+			"street":            "375 BEALE ST",
+			"street_secondary":  "Suite 300",
+			"city":              "SAN FRANCISCO",
+			"region":            "CA",
+			"postal_code":       "94105",
+			"iso_country":       "US",
+			"emergency_enabled": false,
+			"validated":         true,
+			"verified":          false,
+			"date_created":      "Thu, 30 Jul 2015 20:12:31 +0000",
+			"date_updated":      "Thu, 30 Jul 2015 20:12:33 +0000",
+		}
+
+		for key, value := range overrides {
+			a[key] = value
+		}
+
+		body, err := json.Marshal(a)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		return string(body)
+	}
+
+	BeforeEach(func() {
+		var err error
+
+		server = newFakeTwilioServer()
+		provider, err = configuredProvider(map[string]interface{}{"endpoint": server.URL})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		address = provider.ResourcesMap["twilio_address"]
+
+		config = map[string]interface{}{
+			"customer_name":    "Woomy Inc",
+			"friendly_name":    "Head office",
+			"street":           "375 Beale St.",
+			"street_secondary": "Suite 300",
+			"city":             "San Francisco",
+			"region":           "CA",
+			"postal_code":      "94105",
+			"iso_country":      "US",
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("When applied and refreshed", func() {
+		BeforeEach(func() {
+			server.On(http.MethodPost, accountPath("Addresses"), http.StatusCreated, twilioAddress(nil))
+			server.On(http.MethodGet, accountPath("Addresses/AD123"), http.StatusOK, twilioAddress(nil))
+		})
+
+		It("should create the address and expose its validation status", func() {
+			state, err := applyResource(address, nil, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(state.ID).To(Equal("AD123"))
+			Expect(state.Attributes["validated"]).To(Equal("true"))
+			Expect(state.Attributes["verified"]).To(Equal("false"))
+			Expect(state.Attributes["date_created"]).To(Equal("2015-07-30T20:12:31Z"))
+
+			Expect(server.Requests()[0].Form).To(Equal(map[string][]string{
+				"CustomerName":       {"Woomy Inc"},
+				"FriendlyName":       {"Head office"},
+				"Street":             {"375 Beale St."},
+				"StreetSecondary":    {"Suite 300"},
+				"City":               {"San Francisco"},
+				"Region":             {"CA"},
+				"PostalCode":         {"94105"},
+				"IsoCountry":         {"US"},
+				"EmergencyEnabled":   {"false"},
+				"AutoCorrectAddress": {"true"},
+			}))
+		})
+
+		It("should not plan changes for Twilio's corrections", func() {
+			state, err := applyResource(address, nil, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+
+			plan, err := planResource(address, state, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(plan).To(BeNil())
+		})
+
+		It("should plan Twilio's corrections when `auto_correct` is false", func() {
+			config["auto_correct"] = false
+
+			state, err := applyResource(address, nil, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+
+			plan, err := planResource(address, state, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(plan.Attributes).To(HaveKey("street"))
+			Expect(plan.Attributes).To(HaveKey("city"))
+		})
+
+		It("should only send changed attributes on update", func() {
+			server.On(http.MethodGet, accountPath("Addresses/AD123"), http.StatusOK, twilioAddress(map[string]interface{}{"emergency_enabled": true}))
+			server.On(http.MethodPost, accountPath("Addresses/AD123"), http.StatusOK, twilioAddress(map[string]interface{}{"emergency_enabled": true}))
+
+			state, err := applyResource(address, nil, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+
+			config["emergency_enabled"] = true
+
+			plan, err := planResource(address, state, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(plan.RequiresNew()).To(BeFalse())
+
+			state, err = applyResource(address, state, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(state.Attributes["emergency_enabled"]).To(Equal("true"))
+
+			update := server.Requests()[len(server.Requests())-2]
+			Expect(update.Method).To(Equal(http.MethodPost))
+			Expect(update.Form).To(Equal(map[string][]string{
+				"EmergencyEnabled":   {"true"},
+				"AutoCorrectAddress": {"true"},
+			}))
+		})
+
+		It("should replace the address when `iso_country` changes", func() {
+			state, err := applyResource(address, nil, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+
+			config["iso_country"] = "CA"
+
+			plan, err := planResource(address, state, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(plan.RequiresNew()).To(BeTrue())
+		})
+	})
+
+	Context("When Twilio can't validate the address", func() {
+		It("should point the error at the street", func() {
+			server.On(http.MethodPost, accountPath("Addresses"), http.StatusBadRequest,
+				`{"code": 21628, "message": "Address Validation Error", "more_info": "https://www.twilio.com/docs/errors/21628", "status": 400}`)
+
+			d := address.TestResourceData()
+
+			for key, value := range config {
+				d.Set(key, value)
+			}
+
+			diags := address.CreateContext(context.Background(), d, provider.Meta())
+
+			Expect(diags).To(HaveLen(1))
+			Expect(diags[0].AttributePath).To(Equal(cty.GetAttrPath("street")))
+			Expect(diags[0].Detail).To(ContainSubstring("21628"))
+		})
+	})
+
+	Context("When importing", func() {
+		It("should accept an address in a subaccount", func() {
+			d := address.TestResourceData()
+			d.SetId(fakeSubaccountSID + "/AD123")
+
+			imported, err := address.Importer.StateContext(context.Background(), d, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(imported).To(HaveLen(1))
+			Expect(imported[0].Id()).To(Equal("AD123"))
+			Expect(imported[0].Get("account_sid")).To(Equal(fakeSubaccountSID))
+		})
+	})
+
+	Context("When the address was deleted outside of Terraform", func() {
+		It("should remove it from state on refresh", func() {
+			d := address.TestResourceData()
+			d.SetId("AD123")
+
+			Expect(readResource(address, d, provider.Meta())).Should(Succeed())
+			Expect(d.Id()).To(BeEmpty())
+		})
+	})
+
+	Context("When destroying", func() {
+		It("should delete the address", func() {
+			server.On(http.MethodDelete, accountPath("Addresses/AD123"), http.StatusNoContent, "")
+
+			d := address.TestResourceData()
+			d.SetId("AD123")
+
+			Expect(deleteResource(address, d, provider.Meta())).Should(Succeed())
+			Expect(server.Requests()).To(HaveLen(1))
+			Expect(server.Requests()[0].Method).To(Equal(http.MethodDelete))
+		})
+	})
+})
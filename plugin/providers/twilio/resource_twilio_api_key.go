@@ -2,30 +2,32 @@ package twilio
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"net/url"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	log "github.com/sirupsen/logrus"
 )
 
 func resourceTwilioApiKey() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceTwilioApiKeyCreate,
-		Read:   resourceTwilioApiKeyRead,
-		Update: resourceTwilioApiKeyUpdate,
-		Delete: resourceTwilioApiKeyDelete,
+		CreateContext: resourceTwilioApiKeyCreate,
+		ReadContext:   resourceTwilioApiKeyRead,
+		UpdateContext: resourceTwilioApiKeyUpdate,
+		DeleteContext: resourceTwilioApiKeyDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: defaultResourceTimeouts(),
 
 		Schema: map[string]*schema.Schema{
 			"sid": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"account_sid": accountSIDSchema("API key"),
 			"friendly_name": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
@@ -55,26 +57,31 @@ func flattenKeyForCreate(d *schema.ResourceData) url.Values {
 	return v
 }
 
-func resourceTwilioApiKeyCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceTwilioApiKeyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Debug("ENTER resourceTwilioApiKeyCreate")
 
-	client := meta.(*TerraformTwilioContext).client
-	context := context.TODO()
+	accountSID := meta.(*TerraformTwilioContext).accountSID(d)
+	client, err := meta.(*TerraformTwilioContext).clientForAccount(accountSID)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	createParams := flattenKeyForCreate(d)
 
 	log.Debug("START client.Keys.Create")
 
-	createResult, err := client.Keys.Create(context, createParams)
+	createResult, err := client.Keys.Create(ctx, createParams)
 
 	if err != nil {
 		log.WithError(err).Error("client.Keys.Create failed")
 
-		return err
+		return twilioErrorDiagnostics("Failed to create key", err, nil)
 	}
 
 	d.SetId(createResult.Sid)
 	d.Set("sid", createResult.Sid)
+	d.Set("account_sid", accountSID)
 	d.Set("secret", createResult.Secret)
 	d.Set("friendly_name", createResult.FriendlyName) // In the event that the name wasn't specified, Twilio generates one for you
 	d.Set("date_created", createResult.DateCreated)
@@ -85,53 +92,66 @@ func resourceTwilioApiKeyCreate(d *schema.ResourceData, meta interface{}) error
 	return nil
 }
 
-func resourceTwilioApiKeyRead(d *schema.ResourceData, meta interface{}) error {
+func resourceTwilioApiKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Debug("ENTER resourceTwilioApiKeyRead")
 
-	client := meta.(*TerraformTwilioContext).client
-	context := context.TODO()
+	accountSID := meta.(*TerraformTwilioContext).accountSID(d)
+	client, err := meta.(*TerraformTwilioContext).clientForAccount(accountSID)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	sid := d.Id()
 
 	log.Debug("START client.Keys.Get")
 
-	key, err := client.Keys.Get(context, sid)
+	key, err := client.Keys.Get(ctx, sid)
+
+	log.Debug("END client.Keys.Get")
+
+	if removeFromStateIfNotFound(d, err) {
+		return nil
+	}
+
+	if err != nil {
+		return twilioErrorDiagnostics(fmt.Sprintf("Failed to refresh key SID %s", sid), err, nil)
+	}
 
 	d.Set("sid", key.Sid)
+	d.Set("account_sid", accountSID)
 	// Not updating the secret as Twilio only returns it on creation, not after
 	d.Set("friendly_name", key.FriendlyName) // In the event that the name wasn't specified, Twilio generates one for you
 	d.Set("date_created", key.DateCreated)
 	d.Set("date_updated", key.DateUpdated)
 
-	log.Debug("END client.Keys.Get")
-
-	if err != nil {
-		return fmt.Errorf("Failed to refresh key: %s", err.Error())
-	}
-
 	return nil
 }
 
-func resourceTwilioApiKeyUpdate(d *schema.ResourceData, meta interface{}) error {
-	return errors.New("Not implemented")
+func resourceTwilioApiKeyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return diag.Errorf("Not implemented")
 }
 
-func resourceTwilioApiKeyDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceTwilioApiKeyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Debug("ENTER resourceTwilioApiKeyDelete")
 
-	client := meta.(*TerraformTwilioContext).client
-	context := context.TODO()
+	accountSID := meta.(*TerraformTwilioContext).accountSID(d)
+	client, err := meta.(*TerraformTwilioContext).clientForAccount(accountSID)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	sid := d.Id()
 
 	log.Debug("START client.Keys.Delete")
 
-	err := client.Keys.Delete(context, sid)
+	err = client.Keys.Delete(ctx, sid)
 
 	log.Debug("END client.Accounts.Delete")
 
 	if err != nil {
-		return fmt.Errorf("Failed to delete key: %s", err.Error())
+		return twilioErrorDiagnostics(fmt.Sprintf("Failed to delete key SID %s", sid), err, nil)
 	}
 
 	return nil
@@ -0,0 +1,123 @@
+package twilio_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const fakeSubaccountSID = "AC11111111111111111111111111111111"
+
+var _ = Describe("twilio_api_key", func() {
+	var (
+		server   *fakeTwilioServer
+		provider *schema.Provider
+		key      *schema.Resource
+	)
+
+	BeforeEach(func() {
+		var err error
+
+		server = newFakeTwilioServer()
+		provider, err = configuredProvider(map[string]interface{}{"endpoint": server.URL})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		key = provider.ResourcesMap["twilio_api_key"]
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("When `account_sid` names a subaccount", func() {
+		subaccountPath := func(pathPart string) string {
+			return fmt.Sprintf("/2010-04-01/Accounts/%s/%s.json", fakeSubaccountSID, pathPart)
+		}
+
+		BeforeEach(func() {
+			server.On("POST", subaccountPath("Keys"), 201, `{"sid": "SK123", "friendly_name": "woomy", "secret": "shh"}`)
+			server.On("GET", subaccountPath("Keys/SK123"), 200, `{"sid": "SK123", "friendly_name": "woomy"}`)
+		})
+
+		It("should manage the key inside the subaccount using the parent credentials", func() {
+			d := key.TestResourceData()
+			d.Set("account_sid", fakeSubaccountSID)
+			d.Set("friendly_name", "woomy")
+
+			Expect(createResource(key, d, provider.Meta())).Should(Succeed())
+			Expect(readResource(key, d, provider.Meta())).Should(Succeed())
+
+			Expect(d.Id()).To(Equal("SK123"))
+			Expect(d.Get("account_sid")).To(Equal(fakeSubaccountSID))
+
+			for _, request := range server.Requests() {
+				Expect(request.Path).To(HavePrefix("/2010-04-01/Accounts/" + fakeSubaccountSID + "/"))
+				Expect(request.Username).To(Equal(fakeAccountSID))
+				Expect(request.Password).To(Equal(fakeAuthToken))
+			}
+		})
+	})
+
+	Context("When `account_sid` isn't set", func() {
+		BeforeEach(func() {
+			server.On("GET", accountPath("Keys/SK123"), 200, `{"sid": "SK123", "friendly_name": "woomy"}`)
+		})
+
+		It("should manage the key in the provider's account", func() {
+			d := key.TestResourceData()
+			d.SetId("SK123")
+
+			Expect(readResource(key, d, provider.Meta())).Should(Succeed())
+			Expect(d.Get("account_sid")).To(Equal(fakeAccountSID))
+		})
+	})
+
+	Context("When the key was deleted outside of Terraform", func() {
+		It("should remove it from state on refresh", func() {
+			d := key.TestResourceData()
+			d.SetId("SK404")
+
+			Expect(readResource(key, d, provider.Meta())).Should(Succeed())
+			Expect(d.Id()).To(BeEmpty())
+		})
+	})
+
+	Context("When Twilio is slower than the operation allows", func() {
+		It("should give up once the context is done", func() {
+			server.Delay = 250 * time.Millisecond
+			server.On("GET", accountPath("Keys/SK123"), 200, `{"sid": "SK123", "friendly_name": "woomy"}`)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+
+			d := key.TestResourceData()
+			d.SetId("SK123")
+
+			err := diagnosticsError(key.ReadContext(ctx, d, provider.Meta()))
+			Expect(err).Should(MatchError(ContainSubstring("Timed out waiting on Twilio")))
+		})
+
+		It("should give up once the configured `timeouts` run out", func() {
+			server.Delay = 250 * time.Millisecond
+			server.On("POST", accountPath("Keys"), 201, `{"sid": "SK123", "friendly_name": "woomy", "secret": "shh"}`)
+
+			Expect(*key.Timeouts.Create).To(Equal(10 * time.Minute))
+
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"friendly_name": "woomy",
+				"timeouts":      []interface{}{map[string]interface{}{"create": "20ms"}},
+			})
+
+			diff, err := key.Diff(context.Background(), nil, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+
+			_, diags := key.Apply(context.Background(), nil, diff, provider.Meta())
+			Expect(diagnosticsError(diags)).Should(MatchError(ContainSubstring("Timed out waiting on Twilio")))
+		})
+	})
+})
@@ -1,15 +1,17 @@
 package twilio
 
 import (
-	"bytes"
 	"context"
-	"errors"
 	"fmt"
 	"net/url"
 	"regexp"
+	"strings"
 	
-	"github.com/hashicorp/terraform/helper/hashcode"
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	twilio "github.com/kevinburke/twilio-go"
 	"github.com/spf13/cast"
 
@@ -18,213 +20,376 @@ import (
 
 func resourceTwilioPhoneNumber() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceTwilioPhoneNumberCreate,
-		Read:   resourceTwilioPhoneNumberRead,
-		Update: resourceTwilioPhoneNumberUpdate,
-		Delete: resourceTwilioPhoneNumberDelete,
+		CreateContext: resourceTwilioPhoneNumberCreate,
+		ReadContext:   resourceTwilioPhoneNumberRead,
+		UpdateContext: resourceTwilioPhoneNumberUpdate,
+		DeleteContext: resourceTwilioPhoneNumberDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: resourceTwilioPhoneNumberImport,
 		},
-
-		Schema: map[string]*schema.Schema{
-			"sid": &schema.Schema{
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "The unique identifier for this phone number.",
-			},
-			"search": &schema.Schema{
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Look for this number sequence anywhere in the phone number.",
-			},
-			"area_code": &schema.Schema{
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Look for a number within this area code.",
-			},
-			"country_code": &schema.Schema{
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Two letter ISO country code in which you want to search for a number. See https://support.twilio.com/hc/en-us/articles/223183068-Twilio-international-phone-number-availability-and-their-capabilities for details on available countries.",
-			},
-			"number": &schema.Schema{
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "The full phone number, including country and area code.",
-			},
-			"friendly_name": &schema.Schema{
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "A friendly, human-readable name by which you can refer to this number.",
-			},
-			"date_created": &schema.Schema{
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "The date the phone number was created.",
-			},
-			"date_updated": &schema.Schema{
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "The date the phone number was laste updated.",
-			},
-			"address_requirements": &schema.Schema{
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "Address requirements imposed on this number, if any.",
+		Timeouts:      defaultResourceTimeouts(),
+		CustomizeDiff: customdiff.Sequence(resourceTwilioPhoneNumberSelectionDiff, resourceTwilioPhoneNumberValidateDiff, resourceTwilioPhoneNumberFaxDiff, resourceTwilioPhoneNumberCustomizeDiff),
+
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceTwilioPhoneNumberV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceTwilioPhoneNumberStateUpgradeV0,
 			},
-			"is_beta": &schema.Schema{
-				Type:        schema.TypeBool,
-				Computed:    true,
-				Description: "Whether or not this phone number is new to Twilio (beta status).",
-			},
-			"is_mms_capable": &schema.Schema{
-				Type:        schema.TypeBool,
-				Computed:    true,
-				Description: "Whether or not this phone number is MMS-capable.",
-			},
-			"is_sms_capable": &schema.Schema{
-				Type:        schema.TypeBool,
-				Computed:    true,
-				Description: "Whether or not this phone number is SMS-capable.",
+		},
+
+		Schema: resourceTwilioPhoneNumberSchema(),
+	}
+}
+
+func resourceTwilioPhoneNumberSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"sid": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The unique identifier for this phone number.",
+		},
+		"account_sid": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "SID of the account or subaccount that owns this phone number. Defaults to the provider's `account_sid`; subaccounts are managed using the provider's credentials. Changing it transfers the number to the new account without releasing it.",
+		},
+		"phone_number": &schema.Schema{
+			Type:          schema.TypeString,
+			Optional:      true,
+			Computed:      true,
+			ForceNew:      true,
+			ValidateFunc:  validation.StringMatch(e164Pattern, "must be a phone number in E.164 format, such as +14155551234"),
+			ConflictsWith: []string{"search", "area_code", "search_criteria"},
+			Description:   "A specific phone number to buy, in E.164 format. Skips the search, so can't be used with `search`, `area_code` or `search_criteria`.",
+		},
+		"search": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Look for this number sequence anywhere in the phone number.",
+		},
+		"area_code": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Look for a number within this area code.",
+		},
+		"search_criteria": searchCriteriaSchema(),
+		"selection": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "first",
+			ValidateFunc: validation.StringInSlice(numberSelectionStrategyNames(), false),
+			Description:  "How to choose between the numbers a search returns. Can be `first` to take the first number Twilio returns, `lowest_lexical`, `closest_to` for the number closest to `closest_to`, `pattern_score` to prefer numbers matching `preferred_patterns` or with repeated digits, or `random_with_seed` to pick at random using `selection_seed`. Defaults to `first`.",
+		},
+		"preferred_patterns": &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringIsValidRegExp,
 			},
-			"is_voice_capable": &schema.Schema{
-				Type:        schema.TypeBool,
-				Computed:    true,
-				Description: "Whether or not this phone number is voice-capable..",
-			},									
-			"sms": &schema.Schema{
-				Type:     schema.TypeSet,
-				MinItems: 0,
-				MaxItems: 1,
-				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"application_sid": &schema.Schema{
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "SID of the Twilio application to invoke when an SMS is sent to this number.",
-						},
-						"primary_http_method": &schema.Schema{
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "The HTTP method for the primary URL. Can be `GET` or `POST`, defaults to `POST`.",
-						},
-						"primary_url": &schema.Schema{
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "The URL called when an SMS is sent to this number.",
-						},
-						"fallback_http_method": &schema.Schema{
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "The HTTP method for the fallback URL. Can be `GET` or `POST`, defaults to `POST`.",
-						},
-						"fallback_url": &schema.Schema{
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "The URL called if the primary URL returns a non-favorable status code.",
-						},
+			Description: "Regular expressions matched against the E.164 form of each number when `selection` is `pattern_score`. Numbers matching earlier patterns are preferred.",
+		},
+		"closest_to": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringMatch(e164Pattern, "must be a phone number in E.164 format, such as +14155551234"),
+			Description:  "The phone number, in E.164 format, to pick the numerically closest number to when `selection` is `closest_to`.",
+		},
+		"selection_seed": &schema.Schema{
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Description: "Seed for picking a number when `selection` is `random_with_seed`. The same seed picks the same number from the same search results.",
+		},
+		"country_code": &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Two letter ISO country code in which you want to search for a number. See https://support.twilio.com/hc/en-us/articles/223183068-Twilio-international-phone-number-availability-and-their-capabilities for details on available countries.",
+		},
+		"number_type": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "local",
+			ValidateFunc: validation.StringInSlice(phoneNumberTypeNames(), false),
+			Description:  "The type of number to search for. Can be `local`, `mobile`, `toll_free`, `national`, `shared_cost`, `voip` or `machine_to_machine`, defaults to `local`. Not every type is available in every country.",
+		},
+		"number": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The full phone number, including country and area code.",
+		},
+		"friendly_name": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "A friendly, human-readable name by which you can refer to this number. Twilio uses the formatted number if not set.",
+		},
+		"date_created": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The date the phone number was created.",
+		},
+		"date_updated": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The date the phone number was laste updated.",
+		},
+		"address_requirements": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Address requirements imposed on this number, if any.",
+		},
+		"is_beta": &schema.Schema{
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether or not this phone number is new to Twilio (beta status).",
+		},
+		"is_mms_capable": &schema.Schema{
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether or not this phone number is MMS-capable.",
+		},
+		"is_sms_capable": &schema.Schema{
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether or not this phone number is SMS-capable.",
+		},
+		"is_voice_capable": &schema.Schema{
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether or not this phone number is voice-capable..",
+		},
+		"is_fax_capable": &schema.Schema{
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether or not this phone number is fax-capable.",
+		},
+		"sms": &schema.Schema{
+			Type:     schema.TypeList,
+			MinItems: 0,
+			MaxItems: 1,
+			Optional: true,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"application_sid": &schema.Schema{
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "SID of the Twilio application to invoke when an SMS is sent to this number.",
+					},
+					"primary_http_method": &schema.Schema{
+						Type:             schema.TypeString,
+						Optional:         true,
+						Default:          "POST",
+						DiffSuppressFunc: suppressHTTPMethodCase,
+						ValidateFunc:     validation.StringInSlice([]string{"GET", "POST"}, true),
+						Description:      "The HTTP method for the primary URL. Can be `GET` or `POST`, in any case, defaults to `POST`.",
+					},
+					"primary_url": &schema.Schema{
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "The URL called when an SMS is sent to this number.",
+					},
+					"fallback_http_method": &schema.Schema{
+						Type:             schema.TypeString,
+						Optional:         true,
+						Default:          "POST",
+						DiffSuppressFunc: suppressHTTPMethodCase,
+						ValidateFunc:     validation.StringInSlice([]string{"GET", "POST"}, true),
+						Description:      "The HTTP method for the fallback URL. Can be `GET` or `POST`, in any case, defaults to `POST`.",
+					},
+					"fallback_url": &schema.Schema{
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "The URL called if the primary URL returns a non-favorable status code.",
 					},
 				},
 			},
-			"status_callback": &schema.Schema{
-				Type:     schema.TypeSet,
-				MinItems: 0,
-				MaxItems: 1,
-				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"url": &schema.Schema{
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "The URL called when a whenever a status change occurs on this number.",
-						},
-						"http_method": &schema.Schema{
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "The HTTP method for the status callback URL. Can be `GET` or `POST`, defaults to `POST`.",
-						},
+		},
+		"status_callback": &schema.Schema{
+			Type:     schema.TypeList,
+			MinItems: 0,
+			MaxItems: 1,
+			Optional: true,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"url": &schema.Schema{
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "The URL called when a whenever a status change occurs on this number.",
+					},
+					"http_method": &schema.Schema{
+						Type:             schema.TypeString,
+						Optional:         true,
+						Default:          "POST",
+						DiffSuppressFunc: suppressHTTPMethodCase,
+						ValidateFunc:     validation.StringInSlice([]string{"GET", "POST"}, true),
+						Description:      "The HTTP method for the status callback URL. Can be `GET` or `POST`, in any case, defaults to `POST`.",
 					},
 				},
 			},
-			"voice": &schema.Schema{
-				Type:     schema.TypeSet,
-				MinItems: 0,
-				MaxItems: 1,
-				Optional: true,
-				Computed: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"application_sid": &schema.Schema{
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "SID of the Twilio application to invoke when a call is started with this number.",
-						},
-						"primary_http_method": &schema.Schema{
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "The HTTP method for the primary URL. Can be `GET` or `POST`, defaults to `POST`.",
-						},
-						"primary_url": &schema.Schema{
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "The URL called when a phone call starts on this number.",
-						},
-						"fallback_http_method": &schema.Schema{
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "The HTTP method for the fallback URL. Can be `GET` or `POST`, defaults to `POST`.",
-						},
-						"fallback_url": &schema.Schema{
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "The URL called if the primary URL returns a non-favorable status code.",
-						},
-						"caller_id_enabled": &schema.Schema{
-							Type:        schema.TypeBool,
-							Optional:    true,
-							Description: "If caller ID is enabled or not for this number. If enabled, incurs additional charge per call (see console for pricing). Can be `true` or `false`, defaults to `false`.",
-						},
-						"receive_mode": &schema.Schema{
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "Determines if the line is set up for voice or fax. Can be `voice` or `fax`, defaults to `voice`.",
-						},
+		},
+		"voice": &schema.Schema{
+			Type:     schema.TypeList,
+			MinItems: 0,
+			MaxItems: 1,
+			Optional: true,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"application_sid": &schema.Schema{
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "SID of the Twilio application to invoke when a call is started with this number.",
+					},
+					"primary_http_method": &schema.Schema{
+						Type:             schema.TypeString,
+						Optional:         true,
+						Default:          "POST",
+						DiffSuppressFunc: suppressHTTPMethodCase,
+						ValidateFunc:     validation.StringInSlice([]string{"GET", "POST"}, true),
+						Description:      "The HTTP method for the primary URL. Can be `GET` or `POST`, in any case, defaults to `POST`.",
+					},
+					"primary_url": &schema.Schema{
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "The URL called when a phone call starts on this number.",
+					},
+					"fallback_http_method": &schema.Schema{
+						Type:             schema.TypeString,
+						Optional:         true,
+						Default:          "POST",
+						DiffSuppressFunc: suppressHTTPMethodCase,
+						ValidateFunc:     validation.StringInSlice([]string{"GET", "POST"}, true),
+						Description:      "The HTTP method for the fallback URL. Can be `GET` or `POST`, in any case, defaults to `POST`.",
+					},
+					"fallback_url": &schema.Schema{
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "The URL called if the primary URL returns a non-favorable status code.",
+					},
+					"caller_id_enabled": &schema.Schema{
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     false,
+						Description: "If caller ID is enabled or not for this number. If enabled, incurs additional charge per call (see console for pricing). Can be `true` or `false`, defaults to `false`.",
+					},
+					"receive_mode": &schema.Schema{
+						Type:         schema.TypeString,
+						Optional:     true,
+						Default:      "voice",
+						ValidateFunc: validation.StringInSlice([]string{"voice", "fax"}, false),
+						Description:  "Determines if the line is set up for voice or fax. Can be `voice` or `fax`, defaults to `voice`. Setting a `fax` block switches it to `fax`.",
 					},
 				},
 			},
-			"address_sid": &schema.Schema{
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "SID of the address associated with this phone number. May be required for certain countries.",
-			},
-			"trunk_sid": &schema.Schema{
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "SID of the voice trunk that will handle calls to this number. If set, overrides any voice URLs or applications: only the trunk will recieve the incoming call.",
-			},
-			"identity_sid": &schema.Schema{
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "SID of the identity associated with the phone number. May be required in certain countries.",
+		},
+		"fax": &schema.Schema{
+			Type:          schema.TypeList,
+			MinItems:      0,
+			MaxItems:      1,
+			Optional:      true,
+			ConflictsWith: []string{"voice"},
+			Description:   "Receive faxes rather than calls on this number. Twilio sends incoming faxes to the voice URLs, so this can't be used with the `voice` block. Remove it to take calls again.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"primary_http_method": &schema.Schema{
+						Type:             schema.TypeString,
+						Optional:         true,
+						Default:          "POST",
+						DiffSuppressFunc: suppressHTTPMethodCase,
+						ValidateFunc:     validation.StringInSlice([]string{"GET", "POST"}, true),
+						Description:      "The HTTP method for the primary URL. Can be `GET` or `POST`, in any case, defaults to `POST`.",
+					},
+					"primary_url": &schema.Schema{
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "The URL called when a fax is received on this number.",
+					},
+					"fallback_http_method": &schema.Schema{
+						Type:             schema.TypeString,
+						Optional:         true,
+						Default:          "POST",
+						DiffSuppressFunc: suppressHTTPMethodCase,
+						ValidateFunc:     validation.StringInSlice([]string{"GET", "POST"}, true),
+						Description:      "The HTTP method for the fallback URL. Can be `GET` or `POST`, in any case, defaults to `POST`.",
+					},
+					"fallback_url": &schema.Schema{
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "The URL called if the primary URL returns a non-favorable status code.",
+					},
+				},
 			},
-			"emergency": &schema.Schema{
-				Type:     schema.TypeSet,
-				MinItems: 0,
-				MaxItems: 1,
-				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"status": &schema.Schema{
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "Status of this phone number. Either `active` or `inactive`.",
-						},
-						"address_sid": &schema.Schema{
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "SID of the address used for emergency calling from this number. The address must be validated before it can be used for emergency purposes.",
-						},
+		},
+		"address_sid": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "SID of the address associated with this phone number. May be required for certain countries.",
+		},
+		"trunk_sid": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "SID of the voice trunk that will handle calls to this number. If set, overrides any voice URLs or applications: only the trunk will recieve the incoming call.",
+		},
+		"identity_sid": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "SID of the identity associated with the phone number. May be required in certain countries.",
+		},
+		"bundle_sid": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "SID of the regulatory bundle associated with the phone number. May be required in certain countries.",
+		},
+		"replace_on_search_change": &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Whether or not to buy a new number when the search attributes change and the current number no longer matches them. When `false`, changing a search attribute only records the new value and keeps the number. Defaults to `false`.",
+		},
+		"release_on_destroy": &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Whether or not to release the number back to Twilio when it's destroyed. If `false`, the number is detached from its webhooks, applications and trunk and left in the account. Defaults to `true`.",
+		},
+		"deletion_protection": &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "If `true`, destroying the number fails. Set it to `false` and apply before the number can be destroyed. Defaults to `false`.",
+		},
+		"emergency": &schema.Schema{
+			Type:     schema.TypeList,
+			MinItems: 0,
+			MaxItems: 1,
+			Optional: true,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"status": &schema.Schema{
+						Type:         schema.TypeString,
+						Optional:     true,
+						Default:      "inactive",
+						ValidateFunc: validation.StringInSlice([]string{"active", "inactive"}, false),
+						Description:  "Status of this phone number. Either `active` or `inactive`.",
+					},
+					"address_sid": &schema.Schema{
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "SID of the address used for emergency calling from this number. The address must have `emergency_enabled` set, and applies wait for Twilio to validate it and register it with the number.",
+					},
+					"address_status": &schema.Schema{
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Status of the emergency address registration, such as `registered`, `unregistered` or `pending-registration`.",
 					},
 				},
 			},
@@ -232,6 +397,47 @@ func resourceTwilioPhoneNumber() *schema.Resource {
 	}
 }
 
+// suppressHTTPMethodCase ignores the case of HTTP methods, which Twilio always returns in upper case.
+func suppressHTTPMethodCase(k, old, new string, d *schema.ResourceData) bool {
+	return strings.EqualFold(old, new)
+}
+
+// e164Pattern matches a phone number in E.164 format: a +, the country code and the subscriber number, without punctuation.
+var e164Pattern = regexp.MustCompile(`^\+[1-9]\d{1,14}$`)
+
+// Twilio error codes that are caused by a specific phone number attribute.
+var phoneNumberErrorAttributes = map[string]string{
+	"21451": "area_code",    // Invalid area code
+	"21452": "area_code",    // No phone numbers found in area code
+	"21615": "address_sid",  // Phone number requires a local address
+	"21421": "phone_number", // Phone number is invalid
+	"21422": "phone_number", // Phone number is not available
+}
+
+// noPhoneNumbersFoundDiagnostics explains that a search came back empty, pointing at the narrowest search criteria used.
+func noPhoneNumbersFoundDiagnostics(d *schema.ResourceData) diag.Diagnostics {
+	countryCode := d.Get("country_code").(string)
+	numberType := d.Get("number_type").(string)
+
+	if areaCode := d.Get("area_code").(string); areaCode != "" {
+		return attributeErrorDiagnostics("area_code", "No phone numbers found", fmt.Sprintf("No phone numbers are available in area code %s in %s.", areaCode, countryCode))
+	}
+
+	if search := d.Get("search").(string); search != "" {
+		return attributeErrorDiagnostics("search", "No phone numbers found", fmt.Sprintf("No phone numbers in %s match the search %q.", countryCode, search))
+	}
+
+	if len(d.Get("search_criteria").([]interface{})) > 0 {
+		return attributeErrorDiagnostics("search_criteria", "No phone numbers found", fmt.Sprintf("No %s phone numbers in %s match the search criteria.", numberType, countryCode))
+	}
+
+	if numberType != "local" {
+		return attributeErrorDiagnostics("number_type", "No phone numbers found", fmt.Sprintf("No %s phone numbers are available in %s.", numberType, countryCode))
+	}
+
+	return attributeErrorDiagnostics("country_code", "No phone numbers found", fmt.Sprintf("No phone numbers are available in %s.", countryCode))
+}
+
 func addIfNotEmpty(params url.Values, key string, value interface{}) {
 	s := cast.ToString(value)
 
@@ -240,227 +446,333 @@ func addIfNotEmpty(params url.Values, key string, value interface{}) {
 	}
 }
 
-func makeCreateRequestPayload(d *schema.ResourceData) url.Values {
-	createRequestPayload := make(url.Values)
-
-	addIfNotEmpty(createRequestPayload, "FriendlyName", d.Get("friendly_name"))
-	addIfNotEmpty(createRequestPayload, "AddressSid", d.Get("address_sid"))
-	addIfNotEmpty(createRequestPayload, "TrunkSid", d.Get("trunk_sid"))
-	addIfNotEmpty(createRequestPayload, "IdentitySid", d.Get("identity_sid"))
+// makePhoneNumberPayload builds the parameters Twilio takes for an incoming phone number, reading each attribute with
+// get. Parameters whose attributes aren't set are empty.
+func makePhoneNumberPayload(get func(key string) interface{}) map[string]string {
+	payload := map[string]string{
+		"FriendlyName": cast.ToString(get("friendly_name")),
+		"AddressSid":   cast.ToString(get("address_sid")),
+		"TrunkSid":     cast.ToString(get("trunk_sid")),
+		"IdentitySid":  cast.ToString(get("identity_sid")),
+		"BundleSid":    cast.ToString(get("bundle_sid")),
+	}
 
-	if sms := d.Get("sms").(*schema.Set); sms.Len() > 0 {
-		sms := sms.List()[0].(map[string]interface{})
+	block := func(key string) map[string]interface{} {
+		if list := get(key).([]interface{}); len(list) > 0 && list[0] != nil {
+			return list[0].(map[string]interface{})
+		}
 
-		addIfNotEmpty(createRequestPayload, "SmsApplicationSid", sms["application_sid"])
-		addIfNotEmpty(createRequestPayload, "SmsFallbackUrl", sms["fallback_url"])
-		addIfNotEmpty(createRequestPayload, "SmsFallbackMethod", sms["fallback_http_method"])
-		addIfNotEmpty(createRequestPayload, "SmsMethod", sms["primary_http_method"])
-		addIfNotEmpty(createRequestPayload, "SmsUrl", sms["primary_url"])
+		return nil
 	}
 
-	if voice := d.Get("voice").(*schema.Set); voice.Len() > 0 {
-		voice := voice.List()[0].(map[string]interface{})
+	if sms := block("sms"); sms != nil {
+		payload["SmsApplicationSid"] = cast.ToString(sms["application_sid"])
+		payload["SmsFallbackUrl"] = cast.ToString(sms["fallback_url"])
+		payload["SmsFallbackMethod"] = strings.ToUpper(cast.ToString(sms["fallback_http_method"]))
+		payload["SmsMethod"] = strings.ToUpper(cast.ToString(sms["primary_http_method"]))
+		payload["SmsUrl"] = cast.ToString(sms["primary_url"])
+	}
 
-		addIfNotEmpty(createRequestPayload, "VoiceApplicationSid", voice["application_sid"])
-		addIfNotEmpty(createRequestPayload, "VoiceFallbackUrl", voice["fallback_url"])
-		addIfNotEmpty(createRequestPayload, "VoiceFallbackMethod", voice["fallback_http_method"]) // TODO Map to safe values
-		addIfNotEmpty(createRequestPayload, "VoiceMethod", voice["primary_http_method"])          // TODO Map to safe values
-		addIfNotEmpty(createRequestPayload, "VoiceUrl", voice["primary_url"])
-		addIfNotEmpty(createRequestPayload, "VoiceCallerIdLookup", voice["caller_id_enabled"])
-		addIfNotEmpty(createRequestPayload, "VoiceReceiveMode", voice["receive_mode"]) // TODO Map to Twilio
+	if voice := block("voice"); voice != nil {
+		payload["VoiceApplicationSid"] = cast.ToString(voice["application_sid"])
+		payload["VoiceFallbackUrl"] = cast.ToString(voice["fallback_url"])
+		payload["VoiceFallbackMethod"] = strings.ToUpper(cast.ToString(voice["fallback_http_method"]))
+		payload["VoiceMethod"] = strings.ToUpper(cast.ToString(voice["primary_http_method"]))
+		payload["VoiceUrl"] = cast.ToString(voice["primary_url"])
+		payload["VoiceCallerIdLookup"] = cast.ToString(voice["caller_id_enabled"])
+		payload["VoiceReceiveMode"] = cast.ToString(voice["receive_mode"])
 	}
 
-	if statusCallback := d.Get("status_callback").(*schema.Set); statusCallback.Len() > 0 {
-		statusCallback := statusCallback.List()[0].(map[string]interface{})
+	if statusCallback := block("status_callback"); statusCallback != nil {
+		payload["StatusCallbackMethod"] = strings.ToUpper(cast.ToString(statusCallback["http_method"]))
+		payload["StatusCallback"] = cast.ToString(statusCallback["url"])
+	}
 
-		addIfNotEmpty(createRequestPayload, "StatusCallbackMethod", statusCallback["http_method"]) // TODO Map to safe values
-		addIfNotEmpty(createRequestPayload, "StatusCallback", statusCallback["url"])
+	if emergency := block("emergency"); emergency != nil {
+		// Twilio capitalizes emergency statuses, e.g. `Active`
+		payload["EmergencyStatus"] = strings.Title(cast.ToString(emergency["status"]))
+		payload["EmergencyAddressSid"] = cast.ToString(emergency["address_sid"])
 	}
 
-	if emergency := d.Get("emergency").(*schema.Set); emergency.Len() > 0 {
-		emergency := emergency.List()[0].(map[string]interface{})
+	return payload
+}
 
-		addIfNotEmpty(createRequestPayload, "EmergencyStatus", emergency["status"]) // TODO Map to Twilio values
-		addIfNotEmpty(createRequestPayload, "EmergencyAddressSid", emergency["address_sid"])
+func makeCreateRequestPayload(d *schema.ResourceData) url.Values {
+	createRequestPayload := make(url.Values)
+
+	for key, value := range makePhoneNumberPayload(d.Get) {
+		addIfNotEmpty(createRequestPayload, key, value)
 	}
 
 	return createRequestPayload
 }
 
-func hashAnything(item interface{}) int {
-	s := cast.ToString(item)
-	return hashcode.String(s)
-}
+// makeUpdateRequestPayload only sends the parameters that changed, including those that were cleared.
+func makeUpdateRequestPayload(d *schema.ResourceData) url.Values {
+	updateRequestPayload := make(url.Values)
+
+	old := makePhoneNumberPayload(func(key string) interface{} {
+		o, _ := d.GetChange(key)
+		return o
+	})
 
-func hashStringKeyedMap(v interface{}) int {
-	var buf bytes.Buffer
+	new := makePhoneNumberPayload(func(key string) interface{} {
+		_, n := d.GetChange(key)
+		return n
+	})
 
-	if m, ok := v.(map[string]interface{}); ok {
-		for _, value := range m {
-			buf.WriteString(fmt.Sprintf("%s-", cast.ToString(value)))
+	for key, value := range new {
+		if value != old[key] {
+			updateRequestPayload.Set(key, value)
 		}
 	}
 
-	return hashcode.String(buf.String())
+	return updateRequestPayload
 }
 
-func mapTwilioPhoneNumberToTerraform(ph *twilio.IncomingPhoneNumber, d *schema.ResourceData) error {
+func mapTwilioPhoneNumberToTerraform(ph *incomingPhoneNumber, d *schema.ResourceData) error {
 	d.Set("sid", ph.Sid)
-	d.Set("number", ph.PhoneNumber.Local())
+	d.Set("account_sid", ph.AccountSid)
+	d.Set("phone_number", ph.PhoneNumber)
+	d.Set("number", ph.PhoneNumber)
 
 	d.Set("friendly_name", ph.FriendlyName)
-	// d.Set("address_sid", p.AddressSid) -- address SID not in twiliogo
-	// d.Set("identity_sid", p.IdentitySid) -- identity SID not in twiliogo
+	d.Set("address_sid", ph.AddressSid)
+	d.Set("identity_sid", ph.IdentitySid)
+	d.Set("bundle_sid", ph.BundleSid)
 	d.Set("trunk_sid", ph.TrunkSid)
 
-	if ph.DateCreated.Valid {
-		d.Set("date_created", ph.DateCreated.Time.Format("2006-01-02T15:04:05-07:00"))
-	}
-
-	if ph.DateUpdated.Valid {
-		d.Set("date_updated", ph.DateUpdated.Time.Format("2006-01-02T15:04:05-07:00"))
-	}
+	d.Set("date_created", formatTwilioDate(ph.DateCreated))
+	d.Set("date_updated", formatTwilioDate(ph.DateUpdated))
 	d.Set("address_requirements", ph.AddressRequirements)
 	d.Set("is_beta", ph.Beta)
 	d.Set("is_mms_capable", ph.Capabilities.MMS)
 	d.Set("is_sms_capable", ph.Capabilities.SMS)
 	d.Set("is_voice_capable", ph.Capabilities.Voice)
-	// d.Set("is_fax_capable", p.Capabilities.Fax) -- p.Capabilities.Fax not in twiliogo
-
-	// Voice set
-	voiceMap := make(map[string]interface{})
-	voiceMap["application_sid"] = ph.VoiceApplicationSid
-	voiceMap["fallback_url"] = ph.VoiceFallbackURL
-	voiceMap["fallback_http_method"] = ph.VoiceFallbackMethod
-	voiceMap["primary_url"] = ph.VoiceURL
-	voiceMap["primary_http_method"] = ph.VoiceMethod
-	voiceMap["caller_id_enabled"] = ph.VoiceCallerIDLookup
-	// voiceMap["receive_mode"] = p.ReceiveMode -- receive mode not in twiliogo
-	voiceSet := d.Get("voice").(*schema.Set)
-	voiceSet.Add(voiceMap)
-
-	// sms set
-	smsMap := make(map[string]interface{})
-	smsMap["application_sid"] = ph.SMSApplicationSid
-	smsMap["fallback_url"] = ph.SMSFallbackURL
-	smsMap["fallback_http_method"] = ph.SMSFallbackMethod
-	smsMap["primary_url"] = ph.SMSURL
-	smsMap["primary_http_method"] = ph.SMSMethod
-	smsSet := d.Get("sms").(*schema.Set)
-	smsSet.Add(smsMap)
-
-	// status_callback
-	statusCallbackMap := make(map[string]interface{})
-	statusCallbackMap["url"] = ph.SMSFallbackURL
-	statusCallbackMap["http_method"] = ph.SMSFallbackMethod
-	statusCallbackSet := d.Get("status_callback").(*schema.Set)
-	statusCallbackSet.Add(statusCallbackMap)
-
-	// emergency
-	emergencyMap := make(map[string]interface{})
-
-	if ph.EmergencyAddressSid.Valid {
-		emergencyMap["address_sid"] = ph.EmergencyAddressSid.String
-	}
-	emergencyMap["status"] = ph.EmergencyStatus
-	emergencySet := d.Get("emergency").(*schema.Set)
-	emergencySet.Add(emergencyMap)
+	d.Set("is_fax_capable", ph.Capabilities.Fax)
+
+	voice := map[string]interface{}{
+		"application_sid":      ph.VoiceApplicationSid,
+		"fallback_url":         ph.VoiceFallbackURL,
+		"fallback_http_method": ph.VoiceFallbackMethod,
+		"primary_url":          ph.VoiceURL,
+		"primary_http_method":  ph.VoiceMethod,
+		"caller_id_enabled":    ph.VoiceCallerIDLookup,
+		"receive_mode":         ph.VoiceReceiveMode,
+	}
 
-	return nil
-}
+	if err := d.Set("voice", []interface{}{voice}); err != nil {
+		return err
+	}
 
-func resourceTwilioPhoneNumberCreate(d *schema.ResourceData, meta interface{}) error {
-	log.Debug("ENTER resourceTwilioPhoneNumberCreate")
+	// Faxes go to the voice URLs, so the fax block only mirrors them while it's in use and the number receives faxes.
+	// Numbers switched to fax with `voice.receive_mode` keep an empty fax block rather than planning to remove it.
+	fax := []interface{}{}
+
+	if ph.VoiceReceiveMode == "fax" && len(d.Get("fax").([]interface{})) > 0 {
+		fax = append(fax, map[string]interface{}{
+			"fallback_url":         ph.VoiceFallbackURL,
+			"fallback_http_method": ph.VoiceFallbackMethod,
+			"primary_url":          ph.VoiceURL,
+			"primary_http_method":  ph.VoiceMethod,
+		})
+	}
 
-	client := meta.(*TerraformTwilioContext).client
-	config := meta.(*TerraformTwilioContext).configuration
-	context := context.TODO()
+	if err := d.Set("fax", fax); err != nil {
+		return err
+	}
 
-	//var searchParams url.Values
-	searchParams := make(url.Values)
+	sms := map[string]interface{}{
+		"application_sid":      ph.SMSApplicationSid,
+		"fallback_url":         ph.SMSFallbackURL,
+		"fallback_http_method": ph.SMSFallbackMethod,
+		"primary_url":          ph.SMSURL,
+		"primary_http_method":  ph.SMSMethod,
+	}
 
-	areaCode := d.Get("area_code").(string)
-	if len(areaCode) > 0 {
-		searchParams.Set("AreaCode", areaCode)
+	if err := d.Set("sms", []interface{}{sms}); err != nil {
+		return err
 	}
 
-	search := d.Get("search").(string)
-	if len(search) > 0 {
-		searchParams.Set("Contains", search)
+	statusCallback := map[string]interface{}{
+		"url":         ph.StatusCallback,
+		"http_method": ph.StatusCallbackMethod,
+	}
+
+	if err := d.Set("status_callback", []interface{}{statusCallback}); err != nil {
+		return err
 	}
 
+	emergency := map[string]interface{}{
+		"address_sid":    ph.EmergencyAddressSid,
+		"status":         strings.ToLower(ph.EmergencyStatus),
+		"address_status": ph.EmergencyAddressStatus,
+	}
+
+	if err := d.Set("emergency", []interface{}{emergency}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// findAvailablePhoneNumber searches for a phone number matching the search attributes and returns the first one
+// found, in E.164 format.
+func findAvailablePhoneNumber(ctx context.Context, client *twilio.Client, accountSID string, d *schema.ResourceData) (string, diag.Diagnostics) {
+	searchParams := makeSearchParams(d)
+	search := d.Get("search").(string)
 	countryCode := d.Get("country_code").(string)
+	numberType := d.Get("number_type").(string)
 
 	log.WithFields(
 		log.Fields{
-			"account_sid":  config.AccountSID,
+			"account_sid":  accountSID,
 			"country_code": countryCode,
+			"number_type":  numberType,
 		},
-	).Debug("START client.Available.Numbers.Local.GetPage")
+	).Debug("START searchAvailablePhoneNumbers")
 
-	// TODO switch based on the type of number to buy local, mobile, intl
-	searchResult, err := client.AvailableNumbers.Local.GetPage(context, countryCode, searchParams)
+	searchResult, err := searchAvailablePhoneNumbers(ctx, client, countryCode, numberType, searchParams)
 
 	if err != nil {
 		log.WithFields(
 			log.Fields{
-				"account_sid":  config.AccountSID,
+				"account_sid":  accountSID,
 				"country_code": countryCode,
+				"number_type":  numberType,
 			},
 		).Error("Caught an unexpected error when searching for phone numbers")
 
-		return err
+		return "", twilioErrorDiagnostics(fmt.Sprintf("Failed to search for phone numbers in %s", countryCode), err, phoneNumberErrorAttributes)
 	}
 
 	log.WithFields(
 		log.Fields{
-			"account_sid":  config.AccountSID,
+			"account_sid":  accountSID,
 			"country_code": countryCode,
+			"number_type":  numberType,
 			"search":       search,
 			"result_count": len(searchResult.Numbers),
 		},
-	).Debug("END client.Available.Nubmers.Local.GetPage")
+	).Debug("END searchAvailablePhoneNumbers")
 
 	if searchResult != nil && len(searchResult.Numbers) == 0 {
 		log.WithFields(
 			log.Fields{
-				"account_sid":  config.AccountSID,
+				"account_sid":  accountSID,
 				"country_code": countryCode,
 			},
 		).Error("No phone numbers matched the search patterns")
 
-		return errors.New("No numbers found that match your search")
+		return "", noPhoneNumbersFoundDiagnostics(d)
 	}
 
-	// Grab the first number that matches
-	number := searchResult.Numbers[0]
-
 	// Per https://www.twilio.com/docs/phone-numbers/api/incoming-phone-numbers#create-an-incomingphonenumber-resource
-	// the number must be in E.164 format, aka number with +, country code, number, without any other punctuation
-	re := regexp.MustCompile("[ -]")
-	e164Number := re.ReplaceAllLiteralString(number.PhoneNumber.Friendly(), "")
+	// the number must be in E.164 format, aka number with +, country code, number, without any other punctuation,
+	// which is how the search returns them
+	candidates := make([]string, 0, len(searchResult.Numbers))
+	unusableBlock := ""
+
+	for _, number := range searchResult.Numbers {
+		if block := unusablePhoneNumberBlock(d, number.capabilities()); block != "" {
+			unusableBlock = block
+			continue
+		}
+
+		candidates = append(candidates, number.PhoneNumber)
+	}
+
+	if len(candidates) == 0 {
+		log.WithFields(
+			log.Fields{
+				"account_sid":  accountSID,
+				"country_code": countryCode,
+				"block":        unusableBlock,
+			},
+		).Error("None of the phone numbers found can use the configured blocks")
+
+		return "", attributeErrorDiagnostics(unusableBlock, "No phone numbers found", fmt.Sprintf("None of the %d phone numbers found in %s are %s capable, so they can't use the %s block. Set `%s_enabled` in `search_criteria` to only search for numbers that are.", len(searchResult.Numbers), countryCode, strings.ToUpper(unusableBlock), unusableBlock, unusableBlock))
+	}
+
+	selection := makeNumberSelection(d)
+	number := selection.choose(candidates)
+
+	log.WithFields(
+		log.Fields{
+			"account_sid":  accountSID,
+			"selection":    selection.strategy,
+			"phone_number": number,
+		},
+	).Debug("Selected phone number")
+
+	return number, nil
+}
+
+// makeNumberSelection reads how to choose between the numbers a search returns from the resource's attributes.
+func makeNumberSelection(d *schema.ResourceData) numberSelection {
+	selection := numberSelection{
+		strategy:  d.Get("selection").(string),
+		closestTo: d.Get("closest_to").(string),
+		seed:      int64(d.Get("selection_seed").(int)),
+	}
+
+	for _, pattern := range d.Get("preferred_patterns").([]interface{}) {
+		// Patterns were checked by the schema's ValidateFunc, so will compile
+		selection.preferredPatterns = append(selection.preferredPatterns, regexp.MustCompile(pattern.(string)))
+	}
+
+	return selection
+}
+
+func resourceTwilioPhoneNumberCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Debug("ENTER resourceTwilioPhoneNumberCreate")
+
+	accountSID := meta.(*TerraformTwilioContext).accountSID(d)
+	client, err := meta.(*TerraformTwilioContext).clientForAccount(accountSID)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	e164Number := d.Get("phone_number").(string)
+
+	if e164Number == "" {
+		var diags diag.Diagnostics
+
+		if e164Number, diags = findAvailablePhoneNumber(ctx, client, accountSID, d); diags.HasError() {
+			return diags
+		}
+	}
+
+	emergencyAddressSID := emergencyAddressToActivate(d)
+
+	if emergencyAddressSID != "" {
+		if diags := waitForEmergencyAddress(ctx, client, emergencyAddressSID); diags.HasError() {
+			return diags
+		}
+	}
 
 	buyParams := makeCreateRequestPayload(d)
 	buyParams.Set("PhoneNumber", e164Number)
 
 	log.WithFields(
 		log.Fields{
-			"account_sid":  config.AccountSID,
+			"account_sid":  accountSID,
 			"phone_number": e164Number,
 		},
-	).Debug("START client.IncomingNumbers.Create")
+	).Debug("START createIncomingPhoneNumber")
 
-	buyResult, err := client.IncomingNumbers.Create(context, buyParams)
+	buyResult, err := createIncomingPhoneNumber(ctx, client, buyParams)
 
 	if err != nil {
 		log.WithFields(
 			log.Fields{
-				"account_sid":  config.AccountSID,
+				"account_sid":  accountSID,
 				"phone_number": e164Number,
 			},
 		).Error("Caught an error when attempting to purchase phone number: " + err.Error())
 
-		return err
+		return twilioErrorDiagnostics(fmt.Sprintf("Failed to purchase phone number %s", e164Number), err, phoneNumberErrorAttributes)
 	}
 
 	d.SetId(buyResult.Sid)
@@ -469,26 +781,126 @@ func resourceTwilioPhoneNumberCreate(d *schema.ResourceData, meta interface{}) e
 	err = mapTwilioPhoneNumberToTerraform(buyResult, d)
 
 	if err != nil  {
-		return fmt.Errorf("Encountered error while reading buy result for phone number SID %s and mapping it to TF: %s", buyResult.Sid, err)
+		return diag.Errorf("Encountered error while reading buy result for phone number SID %s and mapping it to TF: %s", buyResult.Sid, err)
+	}
+
+	if emergencyAddressSID != "" {
+		registered, diags := waitForEmergencyRegistration(ctx, client, buyResult.Sid)
+
+		if diags.HasError() {
+			return diags
+		}
+
+		if err := mapTwilioPhoneNumberToTerraform(registered, d); err != nil {
+			return diag.Errorf("Encountered an error while mapping Twilio API result to terraform: %s", err)
+		}
+	}
+
+	var diags diag.Diagnostics
+
+	// Numbers found by searching are already known to be capable, but a specific `phone_number` is only checked once bought
+	if block := unusablePhoneNumberBlock(d, buyResult.capabilities()); block != "" {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       fmt.Sprintf("Phone number can't use the %s block", block),
+			Detail:        fmt.Sprintf("%s isn't %s capable, so Twilio won't use the %s block.", e164Number, strings.ToUpper(block), block),
+			AttributePath: cty.GetAttrPath(block),
+		})
 	}
 
 	log.WithFields(
 		log.Fields{
-			"account_sid":      config.AccountSID,
+			"account_sid":      accountSID,
 			"phone_number":     e164Number,
 			"phone_number_sid": buyResult.Sid,
 		},
-	).Debug("END client.IncomingNumbers.Create")
+	).Debug("END createIncomingPhoneNumber")
 
-	return nil
+	return diags
 }
 
-func resourceTwilioPhoneNumberRead(d *schema.ResourceData, meta interface{}) error {
+// resourceTwilioPhoneNumberImport imports a phone number by its SID or its number in E.164 format, optionally prefixed
+// with the SID of the account that owns it, e.g. `PN123...`, `+14155551234` or `AC123.../+14155551234`.
+func resourceTwilioPhoneNumberImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	log.Debug("ENTER resourceTwilioPhoneNumberImport")
+
+	id := d.Id()
+
+	if parts := strings.SplitN(id, "/", 2); len(parts) == 2 {
+		if !strings.HasPrefix(parts[0], "AC") {
+			return nil, fmt.Errorf("Expected an account SID before the / in %q, such as AC123.../+14155551234", id)
+		}
+
+		d.Set("account_sid", parts[0])
+		id = parts[1]
+	}
+
+	// Search attributes aren't known for an existing number, so use their defaults rather than planning to set them
+	d.Set("number_type", "local")
+	d.Set("selection", "first")
+	d.Set("release_on_destroy", true)
+	d.Set("deletion_protection", false)
+	d.Set("replace_on_search_change", false)
+
+	if strings.HasPrefix(id, "PN") {
+		d.SetId(id)
+
+		return []*schema.ResourceData{d}, nil
+	}
+
+	if !e164Pattern.MatchString(id) {
+		return nil, fmt.Errorf("Expected a phone number SID such as PN123... or a phone number in E.164 format such as +14155551234, got %q", id)
+	}
+
+	accountSID := meta.(*TerraformTwilioContext).accountSID(d)
+	client, err := meta.(*TerraformTwilioContext).clientForAccount(accountSID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	log.WithFields(
+		log.Fields{
+			"account_sid":  accountSID,
+			"phone_number": id,
+		},
+	).Debug("START client.IncomingNumbers.GetPage")
+
+	page, err := client.IncomingNumbers.GetPage(ctx, url.Values{"PhoneNumber": []string{id}})
+
+	if err != nil {
+		return nil, fmt.Errorf("Failed to look up phone number %s: %s", id, twilioErrorDetail(err))
+	}
+
+	log.WithFields(
+		log.Fields{
+			"account_sid":  accountSID,
+			"phone_number": id,
+			"result_count": len(page.IncomingPhoneNumbers),
+		},
+	).Debug("END client.IncomingNumbers.GetPage")
+
+	// The PhoneNumber filter also matches partial numbers, so look for the exact one
+	for _, number := range page.IncomingPhoneNumbers {
+		if string(number.PhoneNumber) == id {
+			d.SetId(number.Sid)
+
+			return []*schema.ResourceData{d}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("No phone number %s was found in account %s", id, accountSID)
+}
+
+func resourceTwilioPhoneNumberRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Debug("ENTER resourceTwilioPhoneNumberRead")
 
-	client := meta.(*TerraformTwilioContext).client
-	config := meta.(*TerraformTwilioContext).configuration
-	context := context.TODO()
+	accountSID := meta.(*TerraformTwilioContext).accountSID(d)
+	client, err := meta.(*TerraformTwilioContext).clientForAccount(accountSID)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	log.Debug("Getting SID")
 
@@ -500,88 +912,226 @@ func resourceTwilioPhoneNumberRead(d *schema.ResourceData, meta interface{}) err
 
 	log.WithFields(
 		log.Fields{
-			"account_sid":      config.AccountSID,
+			"account_sid":      accountSID,
 			"phone_number":     phoneNumber,
 			"phone_number_sid": sid,
 		},
-	).Debug("START client.IncomingNumbers.Get")
+	).Debug("START getIncomingPhoneNumber")
 
-	ph, err := client.IncomingNumbers.Get(context, sid)
+	ph, err := getIncomingPhoneNumber(ctx, client, sid)
+
+	if removeFromStateIfNotFound(d, err) {
+		return nil
+	}
 
 	if err != nil {
-		return fmt.Errorf("Encountered an error when getting phone number SID %s: %s", sid, err)
+		return twilioErrorDiagnostics(fmt.Sprintf("Failed to refresh phone number SID %s", sid), err, nil)
 	}
 
 	err = mapTwilioPhoneNumberToTerraform(ph, d)
 
 	if err != nil {
-		return fmt.Errorf("Encountered an error while mapping Twilio API result to terraform: %s", err)
+		return diag.Errorf("Encountered an error while mapping Twilio API result to terraform: %s", err)
 	}
 
 	return nil
 }
 
-func resourceTwilioPhoneNumberUpdate(d *schema.ResourceData, meta interface{}) error {
-	log.Debug("ENTER resourceTwilioPhoneNumberDelete")
+func resourceTwilioPhoneNumberUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Debug("ENTER resourceTwilioPhoneNumberUpdate")
 
-	client := meta.(*TerraformTwilioContext).client
-	config := meta.(*TerraformTwilioContext).configuration
-	context := context.TODO()
+	accountSID := meta.(*TerraformTwilioContext).accountSID(d)
+	client, err := meta.(*TerraformTwilioContext).clientForAccount(accountSID)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	sid := d.Id()
 
-	updatePayload := makeCreateRequestPayload(d)
+	var updateResult *incomingPhoneNumber
+
+	if d.HasChange("account_sid") {
+		var diags diag.Diagnostics
+
+		if updateResult, diags = transferPhoneNumber(ctx, d, meta); diags.HasError() {
+			return diags
+		}
+	}
+
+	updatePayload := makeUpdateRequestPayload(d)
+
+	if emergencyAddressSID := emergencyAddressToActivate(d); emergencyAddressSID != "" {
+		if diags := waitForEmergencyAddress(ctx, client, emergencyAddressSID); diags.HasError() {
+			return diags
+		}
+	}
+
+	if len(updatePayload) == 0 && updateResult == nil {
+		// Only attributes we keep in state changed, such as the search attributes, so there's nothing to send
+		return resourceTwilioPhoneNumberRead(ctx, d, meta)
+	}
+
+	if len(updatePayload) > 0 {
+		log.WithFields(
+			log.Fields{
+				"account_sid": accountSID,
+				"phone_sid":   sid,
+			},
+		).Debug("START updateIncomingPhoneNumber")
+
+		updateResult, err = updateIncomingPhoneNumber(ctx, client, sid, updatePayload)
+
+		if err != nil {
+			return twilioErrorDiagnostics(fmt.Sprintf("Failed to update phone number SID %s", sid), err, phoneNumberErrorAttributes)
+		}
+	}
+
+	if d.HasChange("emergency") {
+		var diags diag.Diagnostics
+
+		if updateResult, diags = waitForEmergencyRegistration(ctx, client, sid); diags.HasError() {
+			return diags
+		}
+	}
+
+	if err := mapTwilioPhoneNumberToTerraform(updateResult, d); err != nil {
+		return diag.Errorf("Encountered an error while mapping Twilio API result to terraform: %s", err)
+	}
 
-	//phoneNumber := d.Get("number").(string)
-	//updatePayload.Set("PhoneNumber", e164Number)
+	return nil
+}
+
+// transferPhoneNumber moves a phone number from the account in state to the account in config, keeping the number.
+// The transfer is requested against the current owner using the provider's credentials, which must belong to the
+// parent of both accounts.
+func transferPhoneNumber(ctx context.Context, d *schema.ResourceData, meta interface{}) (*incomingPhoneNumber, diag.Diagnostics) {
+	sid := d.Id()
+	from, to := d.GetChange("account_sid")
+
+	client, err := meta.(*TerraformTwilioContext).clientForAccount(from.(string))
+
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
 
 	log.WithFields(
 		log.Fields{
-			"account_sid": config.AccountSID,
-			"phone_sid":   sid,
+			"from_account_sid": from,
+			"to_account_sid":   to,
+			"phone_sid":        sid,
 		},
-	).Debug("START client.IncomingNumbers.Update")
+	).Debug("START updateIncomingPhoneNumber, transferring to another account")
 
-	_, err := client.IncomingNumbers.Update(context, sid, updatePayload)
+	transferResult, err := updateIncomingPhoneNumber(ctx, client, sid, url.Values{"AccountSid": []string{to.(string)}})
 
 	if err != nil {
-		return fmt.Errorf("Failed to update phone number SID %s: %s", sid, err)
+		return nil, twilioErrorDiagnostics(fmt.Sprintf("Failed to transfer phone number SID %s from account %s to %s", sid, from, to), err, map[string]string{"20404": "account_sid"})
 	}
 
-	return nil
+	log.WithFields(
+		log.Fields{
+			"from_account_sid": from,
+			"to_account_sid":   to,
+			"phone_sid":        sid,
+		},
+	).Debug("END updateIncomingPhoneNumber, transferring to another account")
+
+	return transferResult, nil
 }
 
-func resourceTwilioPhoneNumberDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceTwilioPhoneNumberDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Debug("ENTER resourceTwilioPhoneNumberDelete")
 
-	client := meta.(*TerraformTwilioContext).client
-	config := meta.(*TerraformTwilioContext).configuration
-	context := context.TODO()
+	accountSID := meta.(*TerraformTwilioContext).accountSID(d)
+	client, err := meta.(*TerraformTwilioContext).clientForAccount(accountSID)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	sid := d.Id()
 	phoneNumber := d.Get("number").(string)
 
+	if d.Get("deletion_protection").(bool) {
+		return attributeErrorDiagnostics(
+			"deletion_protection",
+			"Phone number is protected from deletion",
+			fmt.Sprintf("Phone number %s (SID %s) has `deletion_protection` enabled. Set `deletion_protection = false` and apply before destroying it.", phoneNumber, sid),
+		)
+	}
+
+	// State written before `release_on_destroy` existed doesn't have it, and those numbers were always released
+	if releaseOnDestroy, ok := d.GetOkExists("release_on_destroy"); ok && !releaseOnDestroy.(bool) {
+		return detachPhoneNumber(ctx, client, accountSID, d)
+	}
+
 	log.WithFields(
 		log.Fields{
-			"account_sid":      config.AccountSID,
+			"account_sid":      accountSID,
 			"phone_number":     phoneNumber,
 			"phone_number_sid": sid,
 		},
 	).Debug("START client.IncomingNumbers.Release")
 
-	err := client.IncomingNumbers.Release(context, sid)
+	err = client.IncomingNumbers.Release(ctx, sid)
 
 	log.WithFields(
 		log.Fields{
-			"account_sid":      config.AccountSID,
+			"account_sid":      accountSID,
 			"phone_number":     phoneNumber,
 			"phone_number_sid": sid,
 		},
 	).Debug("END client.IncomingNumbers.Release")
 
 	if err != nil {
-		return fmt.Errorf("Failed to delete/release number: %s", err.Error())
+		return twilioErrorDiagnostics(fmt.Sprintf("Failed to release phone number SID %s", sid), err, nil)
 	}
 
 	return nil
 }
+
+// detachPhoneNumber clears the webhooks, applications and trunk of a phone number being destroyed without releasing
+// it, so it stops handling traffic but stays in the account.
+func detachPhoneNumber(ctx context.Context, client *twilio.Client, accountSID string, d *schema.ResourceData) diag.Diagnostics {
+	sid := d.Id()
+
+	detachParams := make(url.Values)
+
+	for _, key := range []string{
+		"SmsApplicationSid",
+		"SmsUrl",
+		"SmsFallbackUrl",
+		"VoiceApplicationSid",
+		"VoiceUrl",
+		"VoiceFallbackUrl",
+		"StatusCallback",
+		"TrunkSid",
+	} {
+		detachParams.Set(key, "")
+	}
+
+	log.WithFields(
+		log.Fields{
+			"account_sid":      accountSID,
+			"phone_number_sid": sid,
+		},
+	).Debug("START updateIncomingPhoneNumber, detaching without releasing")
+
+	if _, err := updateIncomingPhoneNumber(ctx, client, sid, detachParams); err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+
+		return twilioErrorDiagnostics(fmt.Sprintf("Failed to detach phone number SID %s", sid), err, nil)
+	}
+
+	log.WithFields(
+		log.Fields{
+			"account_sid":      accountSID,
+			"phone_number_sid": sid,
+		},
+	).Warn("Phone number was detached and left in the account rather than released, as release_on_destroy is false")
+
+	return nil
+}
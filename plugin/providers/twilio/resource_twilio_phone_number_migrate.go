@@ -0,0 +1,245 @@
+package twilio
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	log "github.com/sirupsen/logrus"
+)
+
+// phoneNumberBlocks are the single item blocks of twilio_phone_number that were sets in schema version 0.
+var phoneNumberBlocks = []string{"sms", "status_callback", "voice", "emergency"}
+
+// resourceTwilioPhoneNumberV0 is a frozen copy of the twilio_phone_number schema as it was at schema version 0, used
+// to read state written by it. Don't change it along with the current schema.
+func resourceTwilioPhoneNumberV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"sid": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The unique identifier for this phone number.",
+			},
+			"search": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Look for this number sequence anywhere in the phone number.",
+			},
+			"area_code": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Look for a number within this area code.",
+			},
+			"country_code": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Two letter ISO country code in which you want to search for a number. See https://support.twilio.com/hc/en-us/articles/223183068-Twilio-international-phone-number-availability-and-their-capabilities for details on available countries.",
+			},
+			"number": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The full phone number, including country and area code.",
+			},
+			"friendly_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A friendly, human-readable name by which you can refer to this number.",
+			},
+			"date_created": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date the phone number was created.",
+			},
+			"date_updated": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date the phone number was laste updated.",
+			},
+			"address_requirements": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Address requirements imposed on this number, if any.",
+			},
+			"is_beta": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether or not this phone number is new to Twilio (beta status).",
+			},
+			"is_mms_capable": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether or not this phone number is MMS-capable.",
+			},
+			"is_sms_capable": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether or not this phone number is SMS-capable.",
+			},
+			"is_voice_capable": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether or not this phone number is voice-capable..",
+			},
+			"sms": &schema.Schema{
+				Type:     schema.TypeSet,
+				MinItems: 0,
+				MaxItems: 1,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"application_sid": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "SID of the Twilio application to invoke when an SMS is sent to this number.",
+						},
+						"primary_http_method": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The HTTP method for the primary URL. Can be `GET` or `POST`, defaults to `POST`.",
+						},
+						"primary_url": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The URL called when an SMS is sent to this number.",
+						},
+						"fallback_http_method": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The HTTP method for the fallback URL. Can be `GET` or `POST`, defaults to `POST`.",
+						},
+						"fallback_url": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The URL called if the primary URL returns a non-favorable status code.",
+						},
+					},
+				},
+			},
+			"status_callback": &schema.Schema{
+				Type:     schema.TypeSet,
+				MinItems: 0,
+				MaxItems: 1,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"url": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The URL called when a whenever a status change occurs on this number.",
+						},
+						"http_method": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The HTTP method for the status callback URL. Can be `GET` or `POST`, defaults to `POST`.",
+						},
+					},
+				},
+			},
+			"voice": &schema.Schema{
+				Type:     schema.TypeSet,
+				MinItems: 0,
+				MaxItems: 1,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"application_sid": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "SID of the Twilio application to invoke when a call is started with this number.",
+						},
+						"primary_http_method": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The HTTP method for the primary URL. Can be `GET` or `POST`, defaults to `POST`.",
+						},
+						"primary_url": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The URL called when a phone call starts on this number.",
+						},
+						"fallback_http_method": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The HTTP method for the fallback URL. Can be `GET` or `POST`, defaults to `POST`.",
+						},
+						"fallback_url": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The URL called if the primary URL returns a non-favorable status code.",
+						},
+						"caller_id_enabled": &schema.Schema{
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "If caller ID is enabled or not for this number. If enabled, incurs additional charge per call (see console for pricing). Can be `true` or `false`, defaults to `false`.",
+						},
+						"receive_mode": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Determines if the line is set up for voice or fax. Can be `voice` or `fax`, defaults to `voice`.",
+						},
+					},
+				},
+			},
+			"address_sid": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "SID of the address associated with this phone number. May be required for certain countries.",
+			},
+			"trunk_sid": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "SID of the voice trunk that will handle calls to this number. If set, overrides any voice URLs or applications: only the trunk will recieve the incoming call.",
+			},
+			"identity_sid": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "SID of the identity associated with the phone number. May be required in certain countries.",
+			},
+			"emergency": &schema.Schema{
+				Type:     schema.TypeSet,
+				MinItems: 0,
+				MaxItems: 1,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"status": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Status of this phone number. Either `active` or `inactive`.",
+						},
+						"address_sid": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "SID of the address used for emergency calling from this number. The address must be validated before it can be used for emergency purposes.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// resourceTwilioPhoneNumberStateUpgradeV0 moves the single item blocks from sets to lists. Sets and lists are both
+// stored as JSON arrays, but refreshes could leave extra elements in a version 0 set, so only the first is kept. The
+// next refresh replaces it with what Twilio has.
+func resourceTwilioPhoneNumberStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	for _, block := range phoneNumberBlocks {
+		items, ok := rawState[block].([]interface{})
+
+		if !ok || len(items) <= 1 {
+			continue
+		}
+
+		log.WithFields(
+			log.Fields{
+				"block":    block,
+				"elements": len(items),
+			},
+		).Debug("Dropping extra elements from phone number block")
+
+		rawState[block] = items[:1]
+	}
+
+	return rawState, nil
+}
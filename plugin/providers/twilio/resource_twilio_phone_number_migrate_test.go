@@ -0,0 +1,86 @@
+package twilio_test
+
+import (
+	"context"
+
+	"github.com/Preskton/terraform-provider-twilio/plugin/providers/twilio"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("twilio_phone_number state upgrades", func() {
+	var phoneNumber *schema.Resource
+
+	BeforeEach(func() {
+		phoneNumber = twilio.Provider().ResourcesMap["twilio_phone_number"]
+	})
+
+	It("should be at schema version 1", func() {
+		Expect(phoneNumber.SchemaVersion).To(Equal(1))
+		Expect(phoneNumber.StateUpgraders).To(HaveLen(1))
+		Expect(phoneNumber.StateUpgraders[0].Version).To(Equal(0))
+	})
+
+	It("should read version 0 state with the version 0 schema", func() {
+		attributes := func(attributeTypes map[string]cty.Type) []string {
+			names := []string{}
+			for name := range attributeTypes {
+				names = append(names, name)
+			}
+			return names
+		}
+
+		v0 := phoneNumber.StateUpgraders[0].Type
+
+		Expect(attributes(v0.AttributeTypes())).To(ConsistOf(
+			"id", "sid", "search", "area_code", "country_code", "number", "friendly_name", "date_created", "date_updated",
+			"address_requirements", "is_beta", "is_mms_capable", "is_sms_capable", "is_voice_capable", "sms",
+			"status_callback", "voice", "address_sid", "trunk_sid", "identity_sid", "emergency",
+		))
+
+		for _, block := range []string{"sms", "status_callback", "voice", "emergency"} {
+			Expect(v0.AttributeType(block).IsSetType()).To(BeTrue(), block)
+		}
+
+		Expect(attributes(v0.AttributeType("sms").ElementType().AttributeTypes())).To(ConsistOf(
+			"application_sid", "primary_http_method", "primary_url", "fallback_http_method", "fallback_url",
+		))
+		Expect(attributes(v0.AttributeType("status_callback").ElementType().AttributeTypes())).To(ConsistOf(
+			"url", "http_method",
+		))
+		Expect(attributes(v0.AttributeType("voice").ElementType().AttributeTypes())).To(ConsistOf(
+			"application_sid", "primary_http_method", "primary_url", "fallback_http_method", "fallback_url",
+			"caller_id_enabled", "receive_mode",
+		))
+		Expect(attributes(v0.AttributeType("emergency").ElementType().AttributeTypes())).To(ConsistOf(
+			"status", "address_sid",
+		))
+	})
+
+	It("should keep a single element of each block from version 0", func() {
+		rawState := map[string]interface{}{
+			"id":           "PN123",
+			"country_code": "US",
+			"sms": []interface{}{
+				map[string]interface{}{"primary_url": "https://example.com/one"},
+				map[string]interface{}{"primary_url": "https://example.com/two"},
+			},
+			"voice": []interface{}{
+				map[string]interface{}{"primary_url": "https://example.com/voice"},
+			},
+		}
+
+		upgraded, err := phoneNumber.StateUpgraders[0].Upgrade(context.Background(), rawState, nil)
+
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(upgraded["sms"]).To(Equal([]interface{}{
+			map[string]interface{}{"primary_url": "https://example.com/one"},
+		}))
+		Expect(upgraded["voice"]).To(Equal([]interface{}{
+			map[string]interface{}{"primary_url": "https://example.com/voice"},
+		}))
+		Expect(upgraded["id"]).To(Equal("PN123"))
+	})
+})
@@ -0,0 +1,1089 @@
+package twilio_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("twilio_phone_number", func() {
+	var (
+		server      *fakeTwilioServer
+		provider    *schema.Provider
+		phoneNumber *schema.Resource
+	)
+
+	BeforeEach(func() {
+		var err error
+
+		server = newFakeTwilioServer()
+		provider, err = configuredProvider(map[string]interface{}{"endpoint": server.URL})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		phoneNumber = provider.ResourcesMap["twilio_phone_number"]
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("When the number was released outside of Terraform", func() {
+		It("should remove it from state on refresh", func() {
+			d := phoneNumber.TestResourceData()
+			d.SetId("PN404")
+
+			Expect(readResource(phoneNumber, d, provider.Meta())).Should(Succeed())
+			Expect(d.Id()).To(BeEmpty())
+		})
+	})
+
+	Context("When Twilio has no numbers in the requested area code", func() {
+		It("should point the error at area_code and include Twilio's error code", func() {
+			server.On(http.MethodGet, accountPath("AvailablePhoneNumbers/US/Local"), http.StatusBadRequest,
+				`{"code": 21452, "message": "No phone numbers found in area code", "more_info": "https://www.twilio.com/docs/errors/21452", "status": 400}`)
+
+			d := phoneNumber.TestResourceData()
+			d.Set("country_code", "US")
+			d.Set("number_type", "local")
+			d.Set("area_code", "999")
+
+			diags := phoneNumber.CreateContext(context.Background(), d, provider.Meta())
+
+			Expect(diags).To(HaveLen(1))
+			Expect(diags[0].AttributePath).To(Equal(cty.GetAttrPath("area_code")))
+			Expect(diags[0].Detail).To(ContainSubstring("21452"))
+			Expect(diags[0].Detail).To(ContainSubstring("https://www.twilio.com/docs/errors/21452"))
+		})
+	})
+
+	Context("When `number_type` is toll_free", func() {
+		It("should search for and buy a toll-free number", func() {
+			server.On(http.MethodGet, accountPath("AvailablePhoneNumbers/US/TollFree"), http.StatusOK,
+				`{"available_phone_numbers": [{"friendly_name": "(844) 555-0100", "phone_number": "+18445550100", "iso_country": "US"}]}`)
+			server.On(http.MethodPost, accountPath("IncomingPhoneNumbers"), http.StatusCreated,
+				`{"sid": "PN123", "account_sid": "`+fakeAccountSID+`", "phone_number": "+18445550100", "capabilities": {"voice": true}}`)
+
+			d := phoneNumber.TestResourceData()
+			d.Set("country_code", "US")
+			d.Set("number_type", "toll_free")
+
+			Expect(createResource(phoneNumber, d, provider.Meta())).Should(Succeed())
+			Expect(d.Id()).To(Equal("PN123"))
+
+			requests := server.Requests()
+			Expect(requests).To(HaveLen(2))
+			Expect(requests[1].Form["PhoneNumber"]).To(Equal([]string{"+18445550100"}))
+		})
+	})
+
+	Context("When `search_criteria` is set", func() {
+		It("should only search for numbers matching the criteria", func() {
+			server.On(http.MethodGet, accountPath("AvailablePhoneNumbers/US/Local"), http.StatusOK,
+				`{"available_phone_numbers": [{"friendly_name": "(972) 555-0100", "phone_number": "+19725550100", "iso_country": "US"}]}`)
+			server.On(http.MethodPost, accountPath("IncomingPhoneNumbers"), http.StatusCreated,
+				`{"sid": "PN123", "account_sid": "`+fakeAccountSID+`", "phone_number": "+19725550100", "capabilities": {"sms": true}}`)
+
+			d := phoneNumber.TestResourceData()
+			d.Set("country_code", "US")
+			d.Set("number_type", "local")
+			d.Set("search_criteria", []interface{}{
+				map[string]interface{}{
+					"sms_enabled":                  true,
+					"exclude_address_requirements": "all",
+					"beta":                         false,
+					"near_lat_long":                "32.78,-96.80",
+					"distance":                     10,
+					"in_region":                    "TX",
+				},
+			})
+
+			Expect(createResource(phoneNumber, d, provider.Meta())).Should(Succeed())
+
+			search := server.Requests()[0]
+			Expect(search.Path).To(Equal(accountPath("AvailablePhoneNumbers/US/Local")))
+			Expect(search.Form).To(Equal(map[string][]string{
+				"SmsEnabled":                {"true"},
+				"ExcludeAllAddressRequired": {"true"},
+				"Beta":                      {"false"},
+				"NearLatLong":               {"32.78,-96.80"},
+				"Distance":                  {"10"},
+				"InRegion":                  {"TX"},
+			}))
+		})
+	})
+
+	Context("When `phone_number` is set", func() {
+		It("should buy that exact number without searching", func() {
+			server.On(http.MethodPost, accountPath("IncomingPhoneNumbers"), http.StatusCreated,
+				`{"sid": "PN123", "account_sid": "`+fakeAccountSID+`", "phone_number": "+14155551234", "capabilities": {"voice": true}}`)
+
+			d := phoneNumber.TestResourceData()
+			d.Set("country_code", "US")
+			d.Set("phone_number", "+14155551234")
+
+			Expect(createResource(phoneNumber, d, provider.Meta())).Should(Succeed())
+			Expect(d.Id()).To(Equal("PN123"))
+			Expect(d.Get("phone_number")).To(Equal("+14155551234"))
+
+			requests := server.Requests()
+			Expect(requests).To(HaveLen(1))
+			Expect(requests[0].Path).To(Equal(accountPath("IncomingPhoneNumbers")))
+			Expect(requests[0].Form["PhoneNumber"]).To(Equal([]string{"+14155551234"}))
+		})
+
+		It("should point at `phone_number` when the number can't be bought", func() {
+			server.On(http.MethodPost, accountPath("IncomingPhoneNumbers"), http.StatusBadRequest,
+				`{"code": 21422, "message": "PhoneNumber is not available", "more_info": "https://www.twilio.com/docs/errors/21422", "status": 400}`)
+
+			d := phoneNumber.TestResourceData()
+			d.Set("country_code", "US")
+			d.Set("phone_number", "+14155551234")
+
+			diags := phoneNumber.CreateContext(context.Background(), d, provider.Meta())
+
+			Expect(diags).To(HaveLen(1))
+			Expect(diags[0].AttributePath).To(Equal(cty.GetAttrPath("phone_number")))
+		})
+
+		It("should reject numbers that aren't in E.164 format", func() {
+			_, errs := phoneNumber.Schema["phone_number"].ValidateFunc("(415) 555-1234", "phone_number")
+
+			Expect(errs).To(HaveLen(1))
+		})
+	})
+
+	Context("When planning a new number", func() {
+		plan := func(config map[string]interface{}) error {
+			_, err := planResource(phoneNumber, nil, config, provider.Meta())
+
+			return err
+		}
+
+		It("should accept a valid area code", func() {
+			Expect(plan(map[string]interface{}{"country_code": "US", "area_code": "415"})).To(Succeed())
+			Expect(plan(map[string]interface{}{"country_code": "GB", "area_code": "20"})).To(Succeed())
+			Expect(plan(map[string]interface{}{"country_code": "US", "number_type": "toll_free", "area_code": "800"})).To(Succeed())
+		})
+
+		It("should reject an unknown country", func() {
+			Expect(plan(map[string]interface{}{"country_code": "XX"})).To(MatchError(ContainSubstring(`country_code "XX"`)))
+		})
+
+		It("should reject an area code that doesn't exist in the country", func() {
+			Expect(plan(map[string]interface{}{"country_code": "US", "area_code": "099"})).To(MatchError(ContainSubstring(`area_code "099" isn't an area code in US`)))
+			Expect(plan(map[string]interface{}{"country_code": "US", "area_code": "41S"})).To(MatchError(ContainSubstring("must only contain digits")))
+		})
+	})
+
+	Context("When a block needs a capability the number may not have", func() {
+		sms := []interface{}{map[string]interface{}{"primary_url": "https://example.com/sms"}}
+
+		It("should only buy a number found by the search that can use the block", func() {
+			server.On(http.MethodGet, accountPath("AvailablePhoneNumbers/GB/Local"), http.StatusOK,
+				`{"available_phone_numbers": [
+					{"phone_number": "+442071838750", "iso_country": "GB", "capabilities": {"voice": true, "SMS": false}},
+					{"phone_number": "+447700900123", "iso_country": "GB", "capabilities": {"voice": true, "SMS": true}}
+				]}`)
+			server.On(http.MethodPost, accountPath("IncomingPhoneNumbers"), http.StatusCreated,
+				`{"sid": "PN123", "account_sid": "`+fakeAccountSID+`", "phone_number": "+447700900123", "capabilities": {"voice": true, "sms": true}}`)
+
+			d := phoneNumber.TestResourceData()
+			d.Set("country_code", "gb")
+			d.Set("number_type", "local")
+			d.Set("sms", sms)
+
+			Expect(createResource(phoneNumber, d, provider.Meta())).Should(Succeed())
+
+			requests := server.Requests()
+			Expect(requests[0].Path).To(Equal(accountPath("AvailablePhoneNumbers/GB/Local")))
+			Expect(requests[1].Form["PhoneNumber"]).To(Equal([]string{"+447700900123"}))
+		})
+
+		It("should point at the block when none of the numbers found can use it", func() {
+			server.On(http.MethodGet, accountPath("AvailablePhoneNumbers/GB/SharedCost"), http.StatusOK,
+				`{"available_phone_numbers": [{"phone_number": "+448431234567", "iso_country": "GB", "capabilities": {"voice": true}}]}`)
+
+			d := phoneNumber.TestResourceData()
+			d.Set("country_code", "GB")
+			d.Set("number_type", "shared_cost")
+			d.Set("sms", sms)
+
+			diags := phoneNumber.CreateContext(context.Background(), d, provider.Meta())
+
+			Expect(diags).To(HaveLen(1))
+			Expect(diags[0].AttributePath).To(Equal(cty.GetAttrPath("sms")))
+			Expect(diags[0].Detail).To(ContainSubstring("can't use the sms block"))
+			Expect(server.Requests()).To(HaveLen(1))
+		})
+
+		It("should warn when a specific `phone_number` can't use the block", func() {
+			server.On(http.MethodPost, accountPath("IncomingPhoneNumbers"), http.StatusCreated,
+				`{"sid": "PN123", "account_sid": "`+fakeAccountSID+`", "phone_number": "+14155551234", "capabilities": {"voice": true}}`)
+
+			d := phoneNumber.TestResourceData()
+			d.Set("country_code", "US")
+			d.Set("phone_number", "+14155551234")
+			d.Set("sms", sms)
+
+			diags := phoneNumber.CreateContext(context.Background(), d, provider.Meta())
+
+			Expect(diags.HasError()).To(BeFalse())
+			Expect(diags).To(HaveLen(1))
+			Expect(diags[0].Severity).To(Equal(diag.Warning))
+			Expect(diags[0].AttributePath).To(Equal(cty.GetAttrPath("sms")))
+			Expect(d.Id()).To(Equal("PN123"))
+		})
+	})
+
+	Context("When choosing between the numbers a search returned", func() {
+		availableNumbers := func(numbers ...string) string {
+			var entries []string
+
+			for _, number := range numbers {
+				entries = append(entries, fmt.Sprintf(`{"phone_number": "%s", "iso_country": "US"}`, number))
+			}
+
+			return `{"available_phone_numbers": [` + strings.Join(entries, ", ") + `]}`
+		}
+
+		// selectNumber creates a phone number with the given settings and returns the number that was bought.
+		selectNumber := func(settings map[string]interface{}) string {
+			d := phoneNumber.TestResourceData()
+			d.Set("country_code", "US")
+			d.Set("number_type", "local")
+
+			for key, value := range settings {
+				Expect(d.Set(key, value)).Should(Succeed())
+			}
+
+			Expect(createResource(phoneNumber, d, provider.Meta())).Should(Succeed())
+
+			requests := server.Requests()
+			return requests[len(requests)-1].Form["PhoneNumber"][0]
+		}
+
+		BeforeEach(func() {
+			server.On(http.MethodGet, accountPath("AvailablePhoneNumbers/US/Local"), http.StatusOK,
+				availableNumbers("+19725550142", "+19725550100", "+19725557777", "+19725550199"))
+			server.On(http.MethodPost, accountPath("IncomingPhoneNumbers"), http.StatusCreated,
+				`{"sid": "PN123", "account_sid": "`+fakeAccountSID+`", "phone_number": "+19725550100", "capabilities": {"voice": true}}`)
+		})
+
+		It("should take the first number Twilio returned by default", func() {
+			Expect(selectNumber(map[string]interface{}{"selection": "first"})).To(Equal("+19725550142"))
+		})
+
+		It("should take the lexically lowest number with `lowest_lexical`", func() {
+			Expect(selectNumber(map[string]interface{}{"selection": "lowest_lexical"})).To(Equal("+19725550100"))
+		})
+
+		It("should take the number closest to `closest_to`", func() {
+			Expect(selectNumber(map[string]interface{}{
+				"selection":  "closest_to",
+				"closest_to": "+19725550190",
+			})).To(Equal("+19725550199"))
+		})
+
+		It("should require `closest_to` with the `closest_to` strategy", func() {
+			config := map[string]interface{}{
+				"country_code": "US",
+				"selection":    "closest_to",
+			}
+
+			_, err := phoneNumber.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), provider.Meta())
+			Expect(err).To(MatchError(ContainSubstring("closest_to must be set")))
+			Expect(server.Requests()).To(BeEmpty())
+		})
+
+		It("should prefer numbers matching `preferred_patterns` with `pattern_score`", func() {
+			Expect(selectNumber(map[string]interface{}{
+				"selection":          "pattern_score",
+				"preferred_patterns": []interface{}{"99$", "^\\+1972"},
+			})).To(Equal("+19725550199"))
+		})
+
+		It("should prefer repeated digits with `pattern_score` when no pattern matches", func() {
+			Expect(selectNumber(map[string]interface{}{
+				"selection":          "pattern_score",
+				"preferred_patterns": []interface{}{"^\\+44"},
+			})).To(Equal("+19725557777"))
+		})
+
+		It("should pick the same number for the same seed with `random_with_seed`, whatever order Twilio returns", func() {
+			settings := map[string]interface{}{
+				"selection":      "random_with_seed",
+				"selection_seed": 42,
+			}
+
+			// The second search returns the same numbers in reverse
+			server.On(http.MethodGet, accountPath("AvailablePhoneNumbers/US/Local"), http.StatusOK,
+				availableNumbers("+19725550199", "+19725557777", "+19725550100", "+19725550142"))
+
+			chosen := selectNumber(settings)
+			Expect([]string{"+19725550142", "+19725550100", "+19725557777", "+19725550199"}).To(ContainElement(chosen))
+			Expect(selectNumber(settings)).To(Equal(chosen))
+		})
+	})
+
+	Context("When importing", func() {
+		importPhoneNumber := func(id string) (*schema.ResourceData, error) {
+			d := phoneNumber.TestResourceData()
+			d.SetId(id)
+
+			imported, err := phoneNumber.Importer.StateContext(context.Background(), d, provider.Meta())
+
+			if err != nil {
+				return nil, err
+			}
+
+			Expect(imported).To(HaveLen(1))
+			return imported[0], nil
+		}
+
+		It("should import by SID", func() {
+			d, err := importPhoneNumber("PN123")
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(d.Id()).To(Equal("PN123"))
+			Expect(server.Requests()).To(BeEmpty())
+		})
+
+		It("should look up the SID of a number in E.164 format", func() {
+			server.On(http.MethodGet, accountPath("IncomingPhoneNumbers"), http.StatusOK, `{"incoming_phone_numbers": [
+				{"sid": "PN999", "phone_number": "+141555512345"},
+				{"sid": "PN123", "phone_number": "+14155551234"}
+			]}`)
+
+			d, err := importPhoneNumber("+14155551234")
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(d.Id()).To(Equal("PN123"))
+			Expect(server.Requests()[0].Form["PhoneNumber"]).To(Equal([]string{"+14155551234"}))
+		})
+
+		It("should look up numbers owned by a subaccount", func() {
+			server.On(http.MethodGet, "/2010-04-01/Accounts/"+fakeSubaccountSID+"/IncomingPhoneNumbers.json", http.StatusOK,
+				`{"incoming_phone_numbers": [{"sid": "PN123", "phone_number": "+14155551234"}]}`)
+
+			d, err := importPhoneNumber(fakeSubaccountSID + "/+14155551234")
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(d.Id()).To(Equal("PN123"))
+			Expect(d.Get("account_sid")).To(Equal(fakeSubaccountSID))
+		})
+
+		It("should fail when the account doesn't have the number", func() {
+			server.On(http.MethodGet, accountPath("IncomingPhoneNumbers"), http.StatusOK, `{"incoming_phone_numbers": []}`)
+
+			_, err := importPhoneNumber("+14155551234")
+
+			Expect(err).Should(MatchError(ContainSubstring("No phone number +14155551234 was found")))
+		})
+
+		It("should reject IDs that are neither a SID nor a phone number", func() {
+			_, err := importPhoneNumber("415-555-1234")
+
+			Expect(err).Should(MatchError(ContainSubstring("E.164")))
+		})
+	})
+
+	Context("When applied and refreshed", func() {
+		incomingPhoneNumber := func(overrides map[string]interface{}) string {
+			number := map[string]interface{}{
+				"sid":                    "PN123",
+				"account_sid":            fakeAccountSID,
+				"phone_number":           "+14155551234",
+				"friendly_name":          "(415) 555-1234",
+				"address_sid":            nil,
+				"address_requirements":   "none",
+				"identity_sid":           nil,
+				"bundle_sid":             nil,
+				"trunk_sid":              nil,
+				"beta":                   false,
+				"date_created":           "Thu, 30 Jul 2015 20:12:31 +0000",
+				"date_updated":           "Thu, 30 Jul 2015 20:12:33 +0000",
+				"emergency_status":       "Inactive",
+				"emergency_address_sid":  nil,
+				"sms_application_sid":    "",
+				"sms_fallback_method":    "POST",
+				"sms_fallback_url":       "",
+				"sms_method":             "POST",
+				"sms_url":                "",
+				"status_callback":        "",
+				"status_callback_method": "POST",
+				"voice_application_sid":  "",
+				"voice_caller_id_lookup": false,
+				"voice_fallback_method":  "POST",
+				"voice_fallback_url":     "",
+				"voice_method":           "POST",
+				"voice_url":              "",
+				"voice_receive_mode":     "voice",
+				"capabilities":           map[string]bool{"voice": true, "sms": true, "mms": true, "fax": false},
+			}
+
+			for key, value := range overrides {
+				number[key] = value
+			}
+
+			body, err := json.Marshal(number)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			return string(body)
+		}
+
+		It("should plan no changes for a minimal config", func() {
+			server.On(http.MethodPost, accountPath("IncomingPhoneNumbers"), http.StatusCreated, incomingPhoneNumber(nil))
+			server.On(http.MethodGet, accountPath("IncomingPhoneNumbers/PN123"), http.StatusOK, incomingPhoneNumber(nil))
+
+			config := map[string]interface{}{
+				"country_code": "US",
+				"phone_number": "+14155551234",
+			}
+
+			state, err := applyResource(phoneNumber, nil, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(state.Attributes["number"]).To(Equal("+14155551234"))
+			Expect(state.Attributes["friendly_name"]).To(Equal("(415) 555-1234"))
+			Expect(state.Attributes["date_created"]).To(Equal("2015-07-30T20:12:31Z"))
+
+			plan, err := planResource(phoneNumber, state, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(plan).To(BeNil())
+		})
+
+		It("should round-trip every block and plan no changes", func() {
+			configured := incomingPhoneNumber(map[string]interface{}{
+				"friendly_name":          "Support",
+				"address_sid":            "AD123",
+				"identity_sid":           "RI123",
+				"bundle_sid":             "BU123",
+				"trunk_sid":              "TK123",
+				"emergency_status":       "Active",
+				"emergency_address_sid":  "AD456",
+				"sms_url":                "https://example.com/sms",
+				"sms_method":             "GET",
+				"status_callback":        "https://example.com/status",
+				"status_callback_method": "GET",
+				"voice_url":              "https://example.com/voice",
+				"voice_caller_id_lookup": true,
+				"voice_receive_mode":     "fax",
+			})
+
+			server.On(http.MethodPost, accountPath("IncomingPhoneNumbers"), http.StatusCreated, configured)
+			server.On(http.MethodGet, accountPath("IncomingPhoneNumbers/PN123"), http.StatusOK, configured)
+			server.On(http.MethodGet, accountPath("Addresses/AD456"), http.StatusOK, `{"sid": "AD456", "emergency_enabled": true, "validated": true}`)
+
+			config := map[string]interface{}{
+				"country_code":  "US",
+				"phone_number":  "+14155551234",
+				"friendly_name": "Support",
+				"address_sid":   "AD123",
+				"identity_sid":  "RI123",
+				"bundle_sid":    "BU123",
+				"trunk_sid":     "TK123",
+				"sms": []interface{}{map[string]interface{}{
+					"primary_url":         "https://example.com/sms",
+					"primary_http_method": "GET",
+				}},
+				"status_callback": []interface{}{map[string]interface{}{
+					"url":         "https://example.com/status",
+					"http_method": "GET",
+				}},
+				"voice": []interface{}{map[string]interface{}{
+					"primary_url":       "https://example.com/voice",
+					"caller_id_enabled": true,
+					"receive_mode":      "fax",
+				}},
+				"emergency": []interface{}{map[string]interface{}{
+					"status":      "active",
+					"address_sid": "AD456",
+				}},
+			}
+
+			state, err := applyResource(phoneNumber, nil, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+
+			// The emergency address is checked before the number is bought
+			Expect(server.Requests()[0].Path).To(Equal(accountPath("Addresses/AD456")))
+
+			create := server.Requests()[1].Form
+			Expect(create["StatusCallback"]).To(Equal([]string{"https://example.com/status"}))
+			Expect(create["StatusCallbackMethod"]).To(Equal([]string{"GET"}))
+			Expect(create["VoiceReceiveMode"]).To(Equal([]string{"fax"}))
+			Expect(create["EmergencyStatus"]).To(Equal([]string{"Active"}))
+			Expect(create["BundleSid"]).To(Equal([]string{"BU123"}))
+
+			plan, err := planResource(phoneNumber, state, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(plan).To(BeNil())
+		})
+
+		It("should accept HTTP methods in any case and plan no changes", func() {
+			configured := incomingPhoneNumber(map[string]interface{}{
+				"sms_url":             "https://example.com/sms",
+				"sms_fallback_method": "GET",
+			})
+
+			server.On(http.MethodPost, accountPath("IncomingPhoneNumbers"), http.StatusCreated, configured)
+			server.On(http.MethodGet, accountPath("IncomingPhoneNumbers/PN123"), http.StatusOK, configured)
+
+			config := map[string]interface{}{
+				"country_code": "US",
+				"phone_number": "+14155551234",
+				"sms": []interface{}{map[string]interface{}{
+					"primary_url":          "https://example.com/sms",
+					"fallback_http_method": "GeT",
+				}},
+			}
+
+			state, err := applyResource(phoneNumber, nil, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(server.Requests()[0].Form["SmsFallbackMethod"]).To(Equal([]string{"GET"}))
+
+			plan, err := planResource(phoneNumber, state, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(plan).To(BeNil())
+		})
+
+		It("should plan changes to nested block attributes precisely", func() {
+			server.On(http.MethodPost, accountPath("IncomingPhoneNumbers"), http.StatusCreated, incomingPhoneNumber(nil))
+			server.On(http.MethodGet, accountPath("IncomingPhoneNumbers/PN123"), http.StatusOK, incomingPhoneNumber(nil))
+
+			config := map[string]interface{}{
+				"country_code": "US",
+				"phone_number": "+14155551234",
+			}
+
+			state, err := applyResource(phoneNumber, nil, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+
+			config["sms"] = []interface{}{map[string]interface{}{"primary_url": "https://example.com/sms"}}
+
+			plan, err := planResource(phoneNumber, state, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(plan.Attributes).To(HaveLen(1))
+			Expect(plan.Attributes).To(HaveKey("sms.0.primary_url"))
+			Expect(plan.RequiresNew()).To(BeFalse())
+		})
+
+		It("should transfer the number in place when `account_sid` changes", func() {
+			subaccountNumberPath := "/2010-04-01/Accounts/" + fakeSubaccountSID + "/IncomingPhoneNumbers/PN123.json"
+			transferred := incomingPhoneNumber(map[string]interface{}{"account_sid": fakeSubaccountSID})
+
+			server.On(http.MethodPost, accountPath("IncomingPhoneNumbers"), http.StatusCreated, incomingPhoneNumber(nil))
+			server.On(http.MethodGet, accountPath("IncomingPhoneNumbers/PN123"), http.StatusOK, incomingPhoneNumber(nil))
+			server.On(http.MethodPost, accountPath("IncomingPhoneNumbers/PN123"), http.StatusOK, transferred)
+			server.On(http.MethodGet, subaccountNumberPath, http.StatusOK, transferred)
+
+			config := map[string]interface{}{
+				"country_code": "US",
+				"phone_number": "+14155551234",
+				"account_sid":  fakeAccountSID,
+			}
+
+			state, err := applyResource(phoneNumber, nil, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+
+			config["account_sid"] = fakeSubaccountSID
+
+			plan, err := planResource(phoneNumber, state, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(plan.RequiresNew()).To(BeFalse())
+
+			state, err = applyResource(phoneNumber, state, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(state.ID).To(Equal("PN123"))
+			Expect(state.Attributes["account_sid"]).To(Equal(fakeSubaccountSID))
+
+			var transfer fakeRequest
+
+			for _, request := range server.Requests() {
+				if request.Method == http.MethodPost && request.Path == accountPath("IncomingPhoneNumbers/PN123") {
+					transfer = request
+				}
+			}
+
+			Expect(transfer.Form).To(Equal(map[string][]string{"AccountSid": {fakeSubaccountSID}}))
+			Expect(transfer.Username).To(Equal(fakeAccountSID))
+
+			plan, err = planResource(phoneNumber, state, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(plan).To(BeNil())
+		})
+
+		It("should only send changed attributes on update, including cleared ones", func() {
+			before := incomingPhoneNumber(map[string]interface{}{"status_callback": "https://example.com/status"})
+			after := incomingPhoneNumber(map[string]interface{}{"sms_url": "https://example.com/sms"})
+
+			server.On(http.MethodPost, accountPath("IncomingPhoneNumbers"), http.StatusCreated, before)
+			server.On(http.MethodGet, accountPath("IncomingPhoneNumbers/PN123"), http.StatusOK, before)
+			server.On(http.MethodGet, accountPath("IncomingPhoneNumbers/PN123"), http.StatusOK, after)
+			server.On(http.MethodPost, accountPath("IncomingPhoneNumbers/PN123"), http.StatusOK, after)
+
+			config := map[string]interface{}{
+				"country_code": "US",
+				"phone_number": "+14155551234",
+				"status_callback": []interface{}{map[string]interface{}{
+					"url": "https://example.com/status",
+				}},
+			}
+
+			state, err := applyResource(phoneNumber, nil, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+
+			config["status_callback"] = []interface{}{map[string]interface{}{"url": ""}}
+			config["sms"] = []interface{}{map[string]interface{}{"primary_url": "https://example.com/sms"}}
+
+			state, err = applyResource(phoneNumber, state, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+
+			var update map[string][]string
+
+			for _, request := range server.Requests() {
+				if request.Method == http.MethodPost && request.Path == accountPath("IncomingPhoneNumbers/PN123") {
+					update = request.Form
+				}
+			}
+
+			Expect(update).To(Equal(map[string][]string{
+				"StatusCallback": {""},
+				"SmsUrl":         {"https://example.com/sms"},
+			}))
+
+			plan, err := planResource(phoneNumber, state, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(plan).To(BeNil())
+		})
+
+		It("should reject blocks for capabilities the number doesn't have", func() {
+			voiceOnly := incomingPhoneNumber(map[string]interface{}{
+				"capabilities": map[string]bool{"voice": true, "sms": false, "mms": false, "fax": false},
+			})
+
+			server.On(http.MethodPost, accountPath("IncomingPhoneNumbers"), http.StatusCreated, voiceOnly)
+			server.On(http.MethodGet, accountPath("IncomingPhoneNumbers/PN123"), http.StatusOK, voiceOnly)
+
+			config := map[string]interface{}{
+				"country_code": "US",
+				"phone_number": "+14155551234",
+			}
+
+			state, err := applyResource(phoneNumber, nil, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+
+			config["voice"] = []interface{}{map[string]interface{}{"primary_url": "https://example.com/voice"}}
+
+			_, err = planResource(phoneNumber, state, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+
+			config["sms"] = []interface{}{map[string]interface{}{"primary_url": "https://example.com/sms"}}
+
+			_, err = planResource(phoneNumber, state, config, provider.Meta())
+			Expect(err).To(MatchError(ContainSubstring("+14155551234 isn't SMS capable")))
+		})
+
+		It("should receive faxes when the `fax` block is set and take calls again with a `voice` block", func() {
+			fax := incomingPhoneNumber(map[string]interface{}{
+				"voice_url":          "https://example.com/fax",
+				"voice_receive_mode": "fax",
+				"capabilities":       map[string]bool{"voice": true, "sms": true, "mms": true, "fax": true},
+			})
+			voice := incomingPhoneNumber(map[string]interface{}{
+				"voice_url":    "https://example.com/voice",
+				"capabilities": map[string]bool{"voice": true, "sms": true, "mms": true, "fax": true},
+			})
+
+			server.On(http.MethodPost, accountPath("IncomingPhoneNumbers"), http.StatusCreated, fax)
+			server.On(http.MethodGet, accountPath("IncomingPhoneNumbers/PN123"), http.StatusOK, fax)
+			server.On(http.MethodGet, accountPath("IncomingPhoneNumbers/PN123"), http.StatusOK, voice)
+			server.On(http.MethodPost, accountPath("IncomingPhoneNumbers/PN123"), http.StatusOK, voice)
+
+			config := map[string]interface{}{
+				"country_code": "US",
+				"phone_number": "+14155551234",
+				"fax":          []interface{}{map[string]interface{}{"primary_url": "https://example.com/fax"}},
+			}
+
+			state, err := applyResource(phoneNumber, nil, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(state.Attributes["is_fax_capable"]).To(Equal("true"))
+			Expect(state.Attributes["voice.0.receive_mode"]).To(Equal("fax"))
+			Expect(state.Attributes["fax.0.primary_url"]).To(Equal("https://example.com/fax"))
+
+			create := server.Requests()[0].Form
+			Expect(create["VoiceReceiveMode"]).To(Equal([]string{"fax"}))
+			Expect(create["VoiceUrl"]).To(Equal([]string{"https://example.com/fax"}))
+
+			plan, err := planResource(phoneNumber, state, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(plan).To(BeNil())
+
+			delete(config, "fax")
+			config["voice"] = []interface{}{map[string]interface{}{"primary_url": "https://example.com/voice"}}
+
+			state, err = applyResource(phoneNumber, state, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(state.Attributes["voice.0.receive_mode"]).To(Equal("voice"))
+			Expect(state.Attributes["fax.#"]).To(Equal("0"))
+
+			var update map[string][]string
+
+			for _, request := range server.Requests() {
+				if request.Method == http.MethodPost && request.Path == accountPath("IncomingPhoneNumbers/PN123") {
+					update = request.Form
+				}
+			}
+
+			Expect(update).To(Equal(map[string][]string{
+				"VoiceReceiveMode": {"voice"},
+				"VoiceUrl":         {"https://example.com/voice"},
+			}))
+
+			plan, err = planResource(phoneNumber, state, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(plan).To(BeNil())
+		})
+
+		It("should accept fax HTTP methods in any case and plan no changes", func() {
+			fax := incomingPhoneNumber(map[string]interface{}{
+				"voice_url":          "https://example.com/fax",
+				"voice_method":       "GET",
+				"voice_receive_mode": "fax",
+				"capabilities":       map[string]bool{"voice": true, "sms": true, "mms": true, "fax": true},
+			})
+
+			server.On(http.MethodPost, accountPath("IncomingPhoneNumbers"), http.StatusCreated, fax)
+			server.On(http.MethodGet, accountPath("IncomingPhoneNumbers/PN123"), http.StatusOK, fax)
+
+			config := map[string]interface{}{
+				"country_code": "US",
+				"phone_number": "+14155551234",
+				"fax": []interface{}{map[string]interface{}{
+					"primary_url":         "https://example.com/fax",
+					"primary_http_method": "get",
+				}},
+			}
+
+			state, err := applyResource(phoneNumber, nil, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(server.Requests()[0].Form["VoiceMethod"]).To(Equal([]string{"GET"}))
+
+			plan, err := planResource(phoneNumber, state, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(plan).To(BeNil())
+		})
+
+		It("should take calls again when the `fax` block is removed without a `voice` block", func() {
+			fax := incomingPhoneNumber(map[string]interface{}{
+				"voice_url":          "https://example.com/fax",
+				"voice_receive_mode": "fax",
+				"capabilities":       map[string]bool{"voice": true, "sms": true, "mms": true, "fax": true},
+			})
+			voice := incomingPhoneNumber(map[string]interface{}{
+				"capabilities": map[string]bool{"voice": true, "sms": true, "mms": true, "fax": true},
+			})
+
+			server.On(http.MethodPost, accountPath("IncomingPhoneNumbers"), http.StatusCreated, fax)
+			server.On(http.MethodGet, accountPath("IncomingPhoneNumbers/PN123"), http.StatusOK, fax)
+			server.On(http.MethodGet, accountPath("IncomingPhoneNumbers/PN123"), http.StatusOK, voice)
+			server.On(http.MethodPost, accountPath("IncomingPhoneNumbers/PN123"), http.StatusOK, voice)
+
+			config := map[string]interface{}{
+				"country_code": "US",
+				"phone_number": "+14155551234",
+				"fax":          []interface{}{map[string]interface{}{"primary_url": "https://example.com/fax"}},
+			}
+
+			state, err := applyResource(phoneNumber, nil, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+
+			delete(config, "fax")
+
+			plan, err := planResource(phoneNumber, state, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(plan.Attributes["voice.0.receive_mode"].New).To(Equal("voice"))
+			Expect(plan.Attributes["voice.0.primary_url"].New).To(Equal(""))
+			Expect(plan.RequiresNew()).To(BeFalse())
+
+			state, err = applyResource(phoneNumber, state, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(state.Attributes["voice.0.receive_mode"]).To(Equal("voice"))
+			Expect(state.Attributes["fax.#"]).To(Equal("0"))
+
+			var update map[string][]string
+
+			for _, request := range server.Requests() {
+				if request.Method == http.MethodPost && request.Path == accountPath("IncomingPhoneNumbers/PN123") {
+					update = request.Form
+				}
+			}
+
+			Expect(update).To(Equal(map[string][]string{
+				"VoiceReceiveMode": {"voice"},
+				"VoiceUrl":         {""},
+			}))
+
+			plan, err = planResource(phoneNumber, state, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(plan).To(BeNil())
+		})
+
+		It("should plan switching back to voice when a number is switched to fax outside of Terraform", func() {
+			server.On(http.MethodPost, accountPath("IncomingPhoneNumbers"), http.StatusCreated, incomingPhoneNumber(nil))
+			server.On(http.MethodGet, accountPath("IncomingPhoneNumbers/PN123"), http.StatusOK, incomingPhoneNumber(nil))
+			server.On(http.MethodGet, accountPath("IncomingPhoneNumbers/PN123"), http.StatusOK, incomingPhoneNumber(map[string]interface{}{
+				"voice_receive_mode": "fax",
+			}))
+
+			config := map[string]interface{}{
+				"country_code": "US",
+				"phone_number": "+14155551234",
+				"voice":        []interface{}{map[string]interface{}{"receive_mode": "voice"}},
+			}
+
+			state, err := applyResource(phoneNumber, nil, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+
+			state, diags := phoneNumber.RefreshWithoutUpgrade(context.Background(), state, provider.Meta())
+			Expect(diags).To(BeEmpty())
+			Expect(state.Attributes["voice.0.receive_mode"]).To(Equal("fax"))
+
+			plan, err := planResource(phoneNumber, state, config, provider.Meta())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(plan.Attributes).To(HaveLen(1))
+			Expect(plan.Attributes["voice.0.receive_mode"].New).To(Equal("voice"))
+			Expect(plan.RequiresNew()).To(BeFalse())
+		})
+
+		Context("When activating emergency calling", func() {
+			emergency := []interface{}{map[string]interface{}{"status": "active", "address_sid": "AD456"}}
+
+			It("should fail before buying the number if the address isn't enabled for emergency calling", func() {
+				server.On(http.MethodGet, accountPath("Addresses/AD456"), http.StatusOK, `{"sid": "AD456", "emergency_enabled": false, "validated": true}`)
+
+				d := phoneNumber.TestResourceData()
+				d.Set("country_code", "US")
+				d.Set("number_type", "local")
+				d.Set("phone_number", "+14155551234")
+				d.Set("emergency", emergency)
+
+				diags := phoneNumber.CreateContext(context.Background(), d, provider.Meta())
+
+				Expect(diags).To(HaveLen(1))
+				Expect(diags[0].Summary).To(Equal("Address isn't enabled for emergency calling"))
+				Expect(diags[0].AttributePath).To(Equal(cty.GetAttrPath("emergency").IndexInt(0).GetAttr("address_sid")))
+				Expect(server.Requests()).To(HaveLen(1))
+			})
+
+			It("should wait for the address to be validated and registered", func() {
+				server.On(http.MethodGet, accountPath("Addresses/AD456"), http.StatusOK, `{"sid": "AD456", "emergency_enabled": true, "validated": false}`)
+				server.On(http.MethodGet, accountPath("Addresses/AD456"), http.StatusOK, `{"sid": "AD456", "emergency_enabled": true, "validated": true}`)
+				server.On(http.MethodPost, accountPath("IncomingPhoneNumbers"), http.StatusCreated, incomingPhoneNumber(map[string]interface{}{
+					"emergency_status":         "Active",
+					"emergency_address_sid":    "AD456",
+					"emergency_address_status": "pending-registration",
+				}))
+				server.On(http.MethodGet, accountPath("IncomingPhoneNumbers/PN123"), http.StatusOK, incomingPhoneNumber(map[string]interface{}{
+					"emergency_status":         "Active",
+					"emergency_address_sid":    "AD456",
+					"emergency_address_status": "registered",
+				}))
+
+				config := map[string]interface{}{
+					"country_code": "US",
+					"phone_number": "+14155551234",
+					"emergency":    emergency,
+				}
+
+				state, err := applyResource(phoneNumber, nil, config, provider.Meta())
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(state.Attributes["emergency.0.status"]).To(Equal("active"))
+				Expect(state.Attributes["emergency.0.address_status"]).To(Equal("registered"))
+
+				paths := []string{}
+
+				for _, request := range server.Requests() {
+					paths = append(paths, request.Method+" "+request.Path)
+				}
+
+				Expect(paths[:4]).To(Equal([]string{
+					"GET " + accountPath("Addresses/AD456"),
+					"GET " + accountPath("Addresses/AD456"),
+					"POST " + accountPath("IncomingPhoneNumbers"),
+					"GET " + accountPath("IncomingPhoneNumbers/PN123"),
+				}))
+
+				plan, err := planResource(phoneNumber, state, config, provider.Meta())
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(plan).To(BeNil())
+			})
+
+			It("should surface a failed registration", func() {
+				server.On(http.MethodPost, accountPath("IncomingPhoneNumbers"), http.StatusCreated, incomingPhoneNumber(nil))
+				server.On(http.MethodGet, accountPath("IncomingPhoneNumbers/PN123"), http.StatusOK, incomingPhoneNumber(nil))
+				server.On(http.MethodGet, accountPath("IncomingPhoneNumbers/PN123"), http.StatusOK, incomingPhoneNumber(map[string]interface{}{
+					"emergency_address_status": "registration-failure",
+				}))
+				server.On(http.MethodGet, accountPath("Addresses/AD456"), http.StatusOK, `{"sid": "AD456", "emergency_enabled": true, "validated": true}`)
+				server.On(http.MethodPost, accountPath("IncomingPhoneNumbers/PN123"), http.StatusOK, incomingPhoneNumber(map[string]interface{}{
+					"emergency_address_status": "pending-registration",
+				}))
+
+				config := map[string]interface{}{
+					"country_code": "US",
+					"phone_number": "+14155551234",
+				}
+
+				state, err := applyResource(phoneNumber, nil, config, provider.Meta())
+				Expect(err).ShouldNot(HaveOccurred())
+
+				config["emergency"] = emergency
+
+				_, err = applyResource(phoneNumber, state, config, provider.Meta())
+				Expect(err).To(MatchError(ContainSubstring("registration-failure")))
+
+				var update map[string][]string
+
+				for _, request := range server.Requests() {
+					if request.Method == http.MethodPost && request.Path == accountPath("IncomingPhoneNumbers/PN123") {
+						update = request.Form
+					}
+				}
+
+				Expect(update["EmergencyStatus"]).To(Equal([]string{"Active"}))
+				Expect(update["EmergencyAddressSid"]).To(Equal([]string{"AD456"}))
+			})
+		})
+
+		Context("When the search of an existing number changes", func() {
+			var config map[string]interface{}
+			var state *terraform.InstanceState
+
+			BeforeEach(func() {
+				server.On(http.MethodGet, accountPath("AvailablePhoneNumbers/US/Local"), http.StatusOK,
+					`{"available_phone_numbers": [{"phone_number": "+14155551234"}]}`)
+				server.On(http.MethodPost, accountPath("IncomingPhoneNumbers"), http.StatusCreated, incomingPhoneNumber(nil))
+				server.On(http.MethodGet, accountPath("IncomingPhoneNumbers/PN123"), http.StatusOK, incomingPhoneNumber(nil))
+
+				config = map[string]interface{}{
+					"country_code": "US",
+					"area_code":    "415",
+				}
+
+				var err error
+				state, err = applyResource(phoneNumber, nil, config, provider.Meta())
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+
+			It("should store the new search without calling Twilio when the number still matches", func() {
+				config["area_code"] = "4155"
+				config["search"] = "55*1234"
+				config["search_criteria"] = []interface{}{map[string]interface{}{"sms_enabled": true}}
+
+				plan, err := planResource(phoneNumber, state, config, provider.Meta())
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(plan.RequiresNew()).To(BeFalse())
+
+				requests := len(server.Requests())
+
+				state, err = applyResource(phoneNumber, state, config, provider.Meta())
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(state.ID).To(Equal("PN123"))
+				Expect(state.Attributes["area_code"]).To(Equal("4155"))
+
+				for _, request := range server.Requests()[requests:] {
+					Expect(request.Method).To(Equal(http.MethodGet))
+				}
+			})
+
+			It("should keep a number that no longer matches by default", func() {
+				config["area_code"] = "212"
+
+				plan, err := planResource(phoneNumber, state, config, provider.Meta())
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(plan.RequiresNew()).To(BeFalse())
+			})
+
+			It("should replace a number that no longer matches when `replace_on_search_change` is set", func() {
+				config["replace_on_search_change"] = true
+				config["area_code"] = "212"
+
+				plan, err := planResource(phoneNumber, state, config, provider.Meta())
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(plan.RequiresNew()).To(BeTrue())
+				Expect(plan.Attributes["area_code"].RequiresNew).To(BeTrue())
+			})
+
+			It("should replace a number without a required capability when `replace_on_search_change` is set", func() {
+				config["replace_on_search_change"] = true
+				config["search_criteria"] = []interface{}{map[string]interface{}{"fax_enabled": true}}
+
+				plan, err := planResource(phoneNumber, state, config, provider.Meta())
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(plan.RequiresNew()).To(BeTrue())
+			})
+
+			It("should not replace a number when only the selection changes", func() {
+				config["replace_on_search_change"] = true
+				config["selection"] = "lowest_lexical"
+
+				plan, err := planResource(phoneNumber, state, config, provider.Meta())
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(plan.RequiresNew()).To(BeFalse())
+			})
+		})
+	})
+
+	Context("When destroying", func() {
+		var d *schema.ResourceData
+
+		BeforeEach(func() {
+			d = phoneNumber.TestResourceData()
+			d.SetId("PN123")
+			d.Set("number", "+14155551234")
+			d.Set("release_on_destroy", true)
+		})
+
+		It("should release the number by default", func() {
+			server.On(http.MethodDelete, accountPath("IncomingPhoneNumbers/PN123"), http.StatusNoContent, "")
+
+			Expect(deleteResource(phoneNumber, d, provider.Meta())).Should(Succeed())
+			Expect(server.Requests()).To(HaveLen(1))
+			Expect(server.Requests()[0].Method).To(Equal(http.MethodDelete))
+		})
+
+		It("should refuse when `deletion_protection` is enabled", func() {
+			d.Set("deletion_protection", true)
+
+			diags := phoneNumber.DeleteContext(context.Background(), d, provider.Meta())
+
+			Expect(diags).To(HaveLen(1))
+			Expect(diags[0].AttributePath).To(Equal(cty.GetAttrPath("deletion_protection")))
+			Expect(diags[0].Detail).To(ContainSubstring("+14155551234"))
+			Expect(server.Requests()).To(BeEmpty())
+		})
+
+		It("should detach the number rather than release it when `release_on_destroy` is false", func() {
+			d.Set("release_on_destroy", false)
+			server.On(http.MethodPost, accountPath("IncomingPhoneNumbers/PN123"), http.StatusOK, `{"sid": "PN123"}`)
+
+			Expect(deleteResource(phoneNumber, d, provider.Meta())).Should(Succeed())
+
+			requests := server.Requests()
+			Expect(requests).To(HaveLen(1))
+			Expect(requests[0].Method).To(Equal(http.MethodPost))
+			Expect(requests[0].Form).To(HaveKeyWithValue("SmsUrl", []string{""}))
+			Expect(requests[0].Form).To(HaveKeyWithValue("VoiceUrl", []string{""}))
+			Expect(requests[0].Form).To(HaveKeyWithValue("TrunkSid", []string{""}))
+		})
+	})
+})
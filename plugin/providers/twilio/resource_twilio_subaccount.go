@@ -2,24 +2,26 @@ package twilio
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"net/url"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	twilio "github.com/kevinburke/twilio-go"
 
 	log "github.com/sirupsen/logrus"
 )
 
 func resourceTwilioSubaccount() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceTwilioSubaccountCreate,
-		Read:   resourceTwilioSubaccountRead,
-		Update: resourceTwilioSubaccountUpdate,
-		Delete: resourceTwilioSubaccountDelete,
+		CreateContext: resourceTwilioSubaccountCreate,
+		ReadContext:   resourceTwilioSubaccountRead,
+		UpdateContext: resourceTwilioSubaccountUpdate,
+		DeleteContext: resourceTwilioSubaccountDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: defaultResourceTimeouts(),
 
 		Schema: map[string]*schema.Schema{
 			"parent_account_sid": &schema.Schema{
@@ -67,13 +69,11 @@ func flattenSubaccountForDelete(d *schema.ResourceData) url.Values {
 	return v
 }
 
-func resourceTwilioSubaccountCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceTwilioSubaccountCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Debug("ENTER resourceTwilioSubaccountCreate")
 
 	client := meta.(*TerraformTwilioContext).client
 	config := meta.(*TerraformTwilioContext).configuration
-	context := context.TODO()
-
 	createParams := flattenSubaccountForCreate(d)
 
 	log.WithFields(
@@ -82,7 +82,7 @@ func resourceTwilioSubaccountCreate(d *schema.ResourceData, meta interface{}) er
 		},
 	).Debug("START client.AccountsCreate")
 
-	createResult, err := client.Accounts.Create(context, createParams)
+	createResult, err := client.Accounts.Create(ctx, createParams)
 
 	if err != nil {
 		log.WithFields(
@@ -91,7 +91,7 @@ func resourceTwilioSubaccountCreate(d *schema.ResourceData, meta interface{}) er
 			},
 		).WithError(err).Error("client.AccountsCreate failed")
 
-		return err
+		return twilioErrorDiagnostics("Failed to create account", err, nil)
 	}
 
 	d.SetId(createResult.Sid)
@@ -112,13 +112,11 @@ func resourceTwilioSubaccountCreate(d *schema.ResourceData, meta interface{}) er
 	return nil
 }
 
-func resourceTwilioSubaccountRead(d *schema.ResourceData, meta interface{}) error {
+func resourceTwilioSubaccountRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Debug("ENTER resourceTwilioSubaccountRead")
 
 	client := meta.(*TerraformTwilioContext).client
 	config := meta.(*TerraformTwilioContext).configuration
-	context := context.TODO()
-
 	sid := d.Id()
 
 	log.WithFields(
@@ -128,14 +126,7 @@ func resourceTwilioSubaccountRead(d *schema.ResourceData, meta interface{}) erro
 		},
 	).Debug("START client.Accounts.Get")
 
-	account, err := client.Accounts.Get(context, sid)
-
-	d.Set("status", account.Status)
-	d.Set("auth_token", account.AuthToken)
-	d.Set("friendly_name", account.FriendlyName) // In the event that the name wasn't specified, Twilio generates one for you
-	d.Set("date_created", account.DateCreated)
-	d.Set("date_updated", account.DateUpdated)
-	d.Set("parent_account_sid", account.OwnerAccountSid)
+	account, err := client.Accounts.Get(ctx, sid)
 
 	log.WithFields(
 		log.Fields{
@@ -144,24 +135,47 @@ func resourceTwilioSubaccountRead(d *schema.ResourceData, meta interface{}) erro
 		},
 	).Debug("END client.AccountsGet")
 
+	if removeFromStateIfNotFound(d, err) {
+		return nil
+	}
+
 	if err != nil {
-		return fmt.Errorf("Failed to refresh account: %s", err.Error())
+		return twilioErrorDiagnostics(fmt.Sprintf("Failed to refresh account SID %s", sid), err, nil)
 	}
 
+	// Closed subaccounts stick around in Twilio for a while but can never be reopened, so treat them as gone
+	if account.Status == twilio.StatusClosed {
+		log.WithFields(
+			log.Fields{
+				"parent_account_sid": config.AccountSID,
+				"subaccount_sid":     sid,
+			},
+		).Warn("Subaccount has been closed, removing it from state")
+
+		d.SetId("")
+
+		return nil
+	}
+
+	d.Set("status", account.Status)
+	d.Set("auth_token", account.AuthToken)
+	d.Set("friendly_name", account.FriendlyName) // In the event that the name wasn't specified, Twilio generates one for you
+	d.Set("date_created", account.DateCreated)
+	d.Set("date_updated", account.DateUpdated)
+	d.Set("parent_account_sid", account.OwnerAccountSid)
+
 	return nil
 }
 
-func resourceTwilioSubaccountUpdate(d *schema.ResourceData, meta interface{}) error {
-	return errors.New("Not implemented")
+func resourceTwilioSubaccountUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return diag.Errorf("Not implemented")
 }
 
-func resourceTwilioSubaccountDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceTwilioSubaccountDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Debug("ENTER resourceTwilioSubaccountDelete")
 
 	client := meta.(*TerraformTwilioContext).client
 	config := meta.(*TerraformTwilioContext).configuration
-	context := context.TODO()
-
 	sid := d.Id()
 
 	updateData := flattenSubaccountForDelete(d)
@@ -173,7 +187,7 @@ func resourceTwilioSubaccountDelete(d *schema.ResourceData, meta interface{}) er
 		},
 	).Debug("START client.Accounts.Delete")
 
-	_, err := client.Accounts.Update(context, sid, updateData)
+	_, err := client.Accounts.Update(ctx, sid, updateData)
 
 	log.WithFields(
 		log.Fields{
@@ -183,7 +197,7 @@ func resourceTwilioSubaccountDelete(d *schema.ResourceData, meta interface{}) er
 	).Debug("END client.Accounts.Delete")
 
 	if err != nil {
-		return fmt.Errorf("Failed to delete account: %s", err.Error())
+		return twilioErrorDiagnostics(fmt.Sprintf("Failed to delete account SID %s", sid), err, nil)
 	}
 
 	return nil
@@ -0,0 +1,64 @@
+package twilio_test
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("twilio_subaccount", func() {
+	var (
+		server     *fakeTwilioServer
+		provider   *schema.Provider
+		subaccount *schema.Resource
+	)
+
+	BeforeEach(func() {
+		var err error
+
+		server = newFakeTwilioServer()
+		provider, err = configuredProvider(map[string]interface{}{"endpoint": server.URL})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		subaccount = provider.ResourcesMap["twilio_subaccount"]
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("When the subaccount exists", func() {
+		It("should refresh it", func() {
+			server.On("GET", "/2010-04-01/Accounts/"+fakeSubaccountSID+".json", 200, `{"sid": "`+fakeSubaccountSID+`", "friendly_name": "woomy", "status": "active", "owner_account_sid": "`+fakeAccountSID+`"}`)
+
+			d := subaccount.TestResourceData()
+			d.SetId(fakeSubaccountSID)
+
+			Expect(readResource(subaccount, d, provider.Meta())).Should(Succeed())
+			Expect(d.Id()).To(Equal(fakeSubaccountSID))
+			Expect(d.Get("parent_account_sid")).To(Equal(fakeAccountSID))
+		})
+	})
+
+	Context("When the subaccount was closed outside of Terraform", func() {
+		It("should remove it from state on refresh", func() {
+			server.On("GET", "/2010-04-01/Accounts/"+fakeSubaccountSID+".json", 200, `{"sid": "`+fakeSubaccountSID+`", "status": "closed"}`)
+
+			d := subaccount.TestResourceData()
+			d.SetId(fakeSubaccountSID)
+
+			Expect(readResource(subaccount, d, provider.Meta())).Should(Succeed())
+			Expect(d.Id()).To(BeEmpty())
+		})
+	})
+
+	Context("When the subaccount doesn't exist", func() {
+		It("should remove it from state on refresh", func() {
+			d := subaccount.TestResourceData()
+			d.SetId(fakeSubaccountSID)
+
+			Expect(readResource(subaccount, d, provider.Meta())).Should(Succeed())
+			Expect(d.Id()).To(BeEmpty())
+		})
+	})
+})
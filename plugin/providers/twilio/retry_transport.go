@@ -0,0 +1,202 @@
+package twilio
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultMaxRetries is how many times a failed Twilio request is retried when `max_retries` isn't set.
+	DefaultMaxRetries = 5
+
+	// DefaultRetryMaxWait is the longest we'll wait between retries when `retry_max_wait` isn't set.
+	DefaultRetryMaxWait = 30 * time.Second
+
+	// retryMinWait is the first backoff interval; it doubles with each retry up to the maximum wait.
+	retryMinWait = 1 * time.Second
+
+	// maxBackoffDoublings stops the backoff interval from overflowing on very large retry counts.
+	maxBackoffDoublings = 16
+
+	// attemptTimeout bounds a single attempt at a request, matching twilio-go's own client timeout.
+	attemptTimeout = 30*time.Second + 500*time.Millisecond
+)
+
+// retryTransport is an http.RoundTripper that retries requests Twilio rejected or failed to serve, with exponential
+// backoff. Requests throttled with a 429 are always retried as Twilio didn't act on them; server errors and network
+// failures are only retried for idempotent methods so we never buy the same number twice.
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	maxWait    time.Duration
+}
+
+// onCloseBody is a response body that runs a function, once, when it's closed.
+type onCloseBody struct {
+	io.ReadCloser
+	once    sync.Once
+	onClose func()
+}
+
+func newOnCloseBody(body io.ReadCloser, onClose func()) *onCloseBody {
+	return &onCloseBody{ReadCloser: body, onClose: onClose}
+}
+
+func (b *onCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.onClose)
+	return err
+}
+
+func newRetryTransport(next http.RoundTripper, maxRetries int, maxWait time.Duration) *retryTransport {
+	return &retryTransport{
+		next:       next,
+		maxRetries: maxRetries,
+		maxWait:    maxWait,
+	}
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		return isIdempotent(req.Method)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(req.Method)
+	default:
+		return false
+	}
+}
+
+// retryAfter parses the Retry-After header, which is either a number of seconds or an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	header := resp.Header.Get("Retry-After")
+
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		wait := time.Until(date)
+
+		if wait < 0 {
+			wait = 0
+		}
+
+		return wait, true
+	}
+
+	return 0, false
+}
+
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	wait, ok := retryAfter(resp)
+
+	if !ok {
+		if attempt > maxBackoffDoublings {
+			attempt = maxBackoffDoublings
+		}
+
+		wait = retryMinWait << uint(attempt)
+		wait = wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+	}
+
+	if wait > t.maxWait {
+		wait = t.maxWait
+	}
+
+	return wait
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+
+		if attempt > 0 && req.Body != nil {
+			body, err := req.GetBody()
+
+			if err != nil {
+				return nil, err
+			}
+
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+
+		ctx, cancel := context.WithTimeout(req.Context(), attemptTimeout)
+		resp, err := t.next.RoundTrip(attemptReq.WithContext(ctx))
+
+		if err != nil {
+			cancel()
+		} else {
+			resp.Body = newOnCloseBody(resp.Body, cancel)
+		}
+
+		rewindable := req.Body == nil || req.GetBody != nil
+
+		if attempt >= t.maxRetries || !rewindable || !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+
+		fields := log.Fields{
+			"method":  req.Method,
+			"url":     req.URL.Path,
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+		}
+
+		if err != nil {
+			log.WithFields(fields).WithError(err).Warn("Twilio request failed, retrying")
+		} else {
+			fields["status"] = resp.StatusCode
+			log.WithFields(fields).Warn("Twilio request was throttled or failed, retrying")
+
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
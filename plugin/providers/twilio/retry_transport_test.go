@@ -0,0 +1,86 @@
+package twilio_test
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Retries", func() {
+	const (
+		throttled   = `{"code": 20429, "message": "Too many requests", "more_info": "https://www.twilio.com/docs/errors/20429", "status": 429}`
+		unavailable = `{"code": 20503, "message": "Service unavailable", "more_info": "https://www.twilio.com/docs/errors/20503", "status": 503}`
+		woomyKey    = `{"sid": "SK123", "friendly_name": "woomy", "secret": "shh"}`
+	)
+
+	var (
+		server   *fakeTwilioServer
+		provider *schema.Provider
+		key      *schema.Resource
+	)
+
+	BeforeEach(func() {
+		var err error
+
+		server = newFakeTwilioServer()
+		provider, err = configuredProvider(map[string]interface{}{
+			"endpoint":       server.URL,
+			"max_retries":    2,
+			"retry_max_wait": "10ms",
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		key = provider.ResourcesMap["twilio_api_key"]
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("When Twilio throttles a request", func() {
+		It("should retry, honoring Retry-After, until it succeeds", func() {
+			server.OnWithHeaders("POST", accountPath("Keys"), 429, map[string]string{"Retry-After": "0"}, throttled)
+			server.On("POST", accountPath("Keys"), 201, woomyKey)
+
+			d := key.TestResourceData()
+			Expect(createResource(key, d, provider.Meta())).Should(Succeed())
+
+			Expect(d.Id()).To(Equal("SK123"))
+			Expect(server.Requests()).To(HaveLen(2))
+			Expect(server.Requests()[1].Form["FriendlyName"]).To(Equal([]string{""}))
+		})
+
+		It("should give up after `max_retries`", func() {
+			server.On("GET", accountPath("Keys/SK123"), 429, throttled)
+
+			d := key.TestResourceData()
+			d.SetId("SK123")
+
+			Expect(readResource(key, d, provider.Meta())).ShouldNot(Succeed())
+			Expect(server.Requests()).To(HaveLen(3))
+		})
+	})
+
+	Context("When Twilio fails with a server error", func() {
+		It("should retry reads", func() {
+			server.On("GET", accountPath("Keys/SK123"), 503, unavailable)
+			server.On("GET", accountPath("Keys/SK123"), 200, woomyKey)
+
+			d := key.TestResourceData()
+			d.SetId("SK123")
+
+			Expect(readResource(key, d, provider.Meta())).Should(Succeed())
+			Expect(server.Requests()).To(HaveLen(2))
+		})
+
+		It("should not retry creates, which may have taken effect", func() {
+			server.On("POST", accountPath("Keys"), 503, unavailable)
+			server.On("POST", accountPath("Keys"), 201, woomyKey)
+
+			d := key.TestResourceData()
+
+			Expect(createResource(key, d, provider.Meta())).ShouldNot(Succeed())
+			Expect(server.Requests()).To(HaveLen(1))
+		})
+	})
+})